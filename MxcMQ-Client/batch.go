@@ -0,0 +1,28 @@
+package MxcMQClient
+
+import (
+	pb "MxcMQ-Client/proto"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// newBatch packs ms into the payload of a single publish, compressed as a
+// whole with c.
+func newBatch(c CompressionType, ms []*Msg) ([]byte, error) {
+	batch := &pb.Batch{Entries: make([]*pb.BatchEntry, 0, len(ms))}
+	for _, m := range ms {
+		batch.Entries = append(batch.Entries, &pb.BatchEntry{
+			Key:        m.Key,
+			Payload:    m.Data,
+			Properties: m.Properties,
+			EventTime:  m.EventTime,
+			DeliverAt:  m.deliverAt(),
+			Ttl:        m.TTL.Milliseconds(),
+		})
+	}
+	data, err := proto.Marshal(batch)
+	if err != nil {
+		return nil, err
+	}
+	return Compress(c, data)
+}
@@ -0,0 +1,16 @@
+package MxcMQClient
+
+import (
+	"errors"
+	"hash/crc32"
+)
+
+var ErrCorruptMsg = errors.New("corrupt message")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Checksum returns the crc32c of payload, it is checked by the broker on
+// publish and by ProcessMsg if the subscriber asks for it.
+func Checksum(payload []byte) uint32 {
+	return crc32.Checksum(payload, crcTable)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
@@ -19,17 +20,54 @@ import (
 type Client struct {
 	Partition           int32
 	OperationMaxRedoNum int32
+	Compression         CompressionType // default of the topic
+	VerifyChecksum      bool
 	conn                *grpc.ClientConn
+	pubMu               sync.Mutex
+	sequenceId          int64 // of the last publish to the partition
 	msgCh               chan Msg
 	pb.UnimplementedClientServer
 }
 
 type Msg struct {
+	Topic        string
+	Partition    int
+	Mid          int64
+	Msid         uint64
+	Key          string // messages with the same key are compacted together
+	Data         []byte
+	Properties   map[string]string
+	EventTime    int64         // unix millisecond, set by the producer
+	DeliverAt    time.Time     // hidden from subscribers until then, if set
+	DeliverAfter time.Duration // hidden from subscribers for this long, if DeliverAt is not set
+	TTL          time.Duration // dropped if not delivered in time, 0 means the ttl of the topic
+	PublishTime  int64         // unix millisecond, set by the broker
+	ProducerName string
+	// failed deliveries of the message to the subscription before this one
+	RedeliveryCount int
+}
+
+// deliverAt returns the deliver time of m in unix millisecond, 0 means now.
+func (m *Msg) deliverAt() int64 {
+	if !m.DeliverAt.IsZero() {
+		return m.DeliverAt.UnixMilli()
+	}
+	if m.DeliverAfter > 0 {
+		return time.Now().Add(m.DeliverAfter).UnixMilli()
+	}
+	return 0
+}
+
+// QuotaError is returned by Publish when the partition is over the storage
+// quota of its topic.
+type QuotaError struct {
 	Topic     string
 	Partition int
-	Mid       int64
-	Msid      uint64
-	Data      []byte
+	Reason    string
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("publish to %v/%v: %v", e.Topic, e.Partition, e.Reason)
 }
 
 type PublishMode int32
@@ -59,7 +97,9 @@ const (
 
 const (
 	RMode_RoundRobinPartition RouteMode = 0
-	RMode_CustomPartition     RouteMode = 1
+	RMode_CustomPartition     RouteMode = 1 // WithpRouter
+	RMode_StickyPartition     RouteMode = 2 // WithpStickyBatchSize messages per partition
+	RMode_KeyHashPartition    RouteMode = 3 // crc32 of Msg.Key, round robin without key
 )
 
 func (c *Client) Msg(ctx context.Context, args *pb.MsgArgs) (*pb.MsgReply, error) {
@@ -97,10 +137,13 @@ func (c *Client) Connect(srvUrl string, args *pb.ConnectArgs) (string, error) {
 		return "nil", err
 	}
 	c.conn = conn
+	args.BinaryPayload = true
 	reply, err := c.Connect2serverWithRedo(args, int(args.Timeout))
 	if err != nil {
 		return "", err
 	}
+	c.Compression = CompressionType(reply.Compression)
+	c.sequenceId = reply.LastSequenceId
 	return reply.Name, nil
 }
 
@@ -217,6 +260,22 @@ func (c *Client) Connet2server(args *pb.ConnectArgs, timeout int) (*pb.ConnectRe
 	return cli.Connect(ctx, args)
 }
 
+// publish gives args the next sequence id of the partition and pushes it.
+// Publishes go one at a time so the broker gets the sequence ids in order and
+// drops the retried ones it persisted already.
+func (c *Client) publish(args *pb.PublishArgs, timeout int) (*pb.PublishReply, error) {
+	c.pubMu.Lock()
+	defer c.pubMu.Unlock()
+	// every message of a batch has its own sequence id
+	if args.BatchSize > 0 {
+		c.sequenceId += int64(args.BatchSize)
+	} else {
+		c.sequenceId++
+	}
+	args.SequenceId = c.sequenceId
+	return c.Push2serverWithRedo(args, timeout)
+}
+
 func (c *Client) Push2serverWithRedo(args *pb.PublishArgs, timeout int) (*pb.PublishReply, error) {
 	if args.Redo >= c.OperationMaxRedoNum {
 		return nil, errors.New("match max redo")
@@ -228,6 +287,10 @@ func (c *Client) Push2serverWithRedo(args *pb.PublishArgs, timeout int) (*pb.Pub
 			args.Redo++
 			return c.Push2serverWithRedo(args, timeout)
 		}
+		if statusErr, ok := status.FromError(err); ok && statusErr.Code() == codes.ResourceExhausted {
+			return nil, &QuotaError{Topic: args.Topic, Partition: int(args.Partition), Reason: statusErr.Message()}
+		}
+		return nil, err
 	}
 	return reply, nil
 }
@@ -327,6 +390,52 @@ func (c *Client) UnSubscribe(args *pb.UnSubscribeArgs, timeout int) (*pb.UnSubsc
 	return cli.ProcessUnSub(ctx, args)
 }
 
+func (c *Client) MsgAckWithRedo(args *pb.MsgAckArgs, timeout int) (*pb.MsgAckReply, error) {
+	if args.Redo >= c.OperationMaxRedoNum {
+		return nil, errors.New("match max redo")
+	}
+
+	reply, err := c.MsgAck(args, timeout)
+	if err != nil {
+		if ok := c.CheckTimeout(err); ok {
+			args.Redo++
+			return c.MsgAckWithRedo(args, timeout)
+		}
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (c *Client) MsgAck(args *pb.MsgAckArgs, timeout int) (*pb.MsgAckReply, error) {
+	cli := pb.NewServerClient(c.conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(timeout))
+	defer cancel()
+	return cli.MsgAck(ctx, args)
+}
+
+func (c *Client) NackWithRedo(args *pb.NackArgs, timeout int) (*pb.NackReply, error) {
+	if args.Redo >= c.OperationMaxRedoNum {
+		return nil, errors.New("match max redo")
+	}
+
+	reply, err := c.Nack(args, timeout)
+	if err != nil {
+		if ok := c.CheckTimeout(err); ok {
+			args.Redo++
+			return c.NackWithRedo(args, timeout)
+		}
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (c *Client) Nack(args *pb.NackArgs, timeout int) (*pb.NackReply, error) {
+	cli := pb.NewServerClient(c.conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(timeout))
+	defer cancel()
+	return cli.Nack(ctx, args)
+}
+
 func (c *Client) CheckTimeout(err error) bool {
 	statusErr, ok := status.FromError(err)
 	if ok && statusErr.Code() == codes.DeadlineExceeded {
@@ -357,12 +466,25 @@ func nrand() int64 {
 func (c *Client) ProcessMsg(ctx context.Context, args *pb.MsgArgs) (*pb.MsgReply, error) {
 	fmt.Printf("Get msg from %v", args)
 	reply := &pb.MsgReply{}
+	if c.VerifyChecksum && args.Crc != 0 && Checksum(args.Payload) != args.Crc {
+		return reply, ErrCorruptMsg
+	}
+	data, err := Decompress(CompressionType(args.Compression), args.Payload, maxMessageSize)
+	if err != nil {
+		return reply, err
+	}
 	msg := Msg{
-		Topic:     args.Topic,
-		Partition: int(args.Partition),
-		Mid:       args.Mid,
-		Msid:      args.Msid,
-		Data:      []byte(args.Payload),
+		Topic:           args.Topic,
+		Partition:       int(args.Partition),
+		Mid:             args.Mid,
+		Msid:            args.Msid,
+		Key:             args.Key,
+		Data:            data,
+		Properties:      args.Properties,
+		EventTime:       args.EventTime,
+		PublishTime:     args.PublishTime,
+		ProducerName:    args.ProducerName,
+		RedeliveryCount: int(args.RedeliveryCount),
 	}
 	c.msgCh <- msg
 	return reply, nil
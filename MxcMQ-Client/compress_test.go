@@ -0,0 +1,24 @@
+package MxcMQClient
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompress(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"key":"value"}`), 100)
+	for _, c := range []CompressionType{Compression_None, Compression_Gzip, Compression_Snappy, Compression_Zstd} {
+		compressed, err := Compress(c, data)
+		assert.Nil(t, err)
+
+		got, err := Decompress(c, compressed, len(data))
+		assert.Nil(t, err)
+		assert.Equal(t, data, got)
+
+		// never decompressed past the limit
+		_, err = Decompress(c, compressed, len(data)-1)
+		assert.ErrorIs(t, err, ErrTooLarge, "codec %v", c)
+	}
+}
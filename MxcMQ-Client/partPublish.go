@@ -14,6 +14,7 @@ type PartPublisher struct {
 	clients            map[string]*Client
 	asyncSends         map[string]*AsyncSend
 	partition2fullname map[int]string
+	router             *router
 }
 
 type AsyncSend struct {
@@ -21,10 +22,17 @@ type AsyncSend struct {
 	asyncSendCh    chan bool
 }
 
-func (p *PartPublisher) msg2part(key int64) int {
-	part := 0
-	part %= int(p.Opt.partitionNum)
-	return part
+// msg2part returns the partition of m, Partition 0 or -1 lets the router
+// choose.
+func (p *PartPublisher) msg2part(m *Msg) (int, error) {
+	part := m.Partition
+	if part <= 0 {
+		part = p.router.route(m, int(p.Opt.partitionNum))
+	}
+	if part < 1 || part > int(p.Opt.partitionNum) {
+		return 0, errors.New(fmt.Sprintf("topic/partition %v does not exist", part))
+	}
+	return part, nil
 }
 
 func NewPartPulisher(srvUrl string, host string, port int, name string, topic string, partition int, opt ...PubOption) *PartPublisher {
@@ -45,6 +53,7 @@ func NewPartPulisher(srvUrl string, host string, port int, name string, topic st
 		clients:            make(map[string]*Client),
 		asyncSends:         make(map[string]*AsyncSend),
 		partition2fullname: make(map[int]string),
+		router:             newRouter(&Option),
 	}
 	return p
 }
@@ -57,8 +66,23 @@ func (p *PartPublisher) Connect() error {
 	}
 
 	for i := 1; i <= int(p.Opt.partitionNum); i++ {
-		client := &Client{}
-		name, err := client.Connect(p.Opt.srvUrl, cliUrl, p.Opt.name, p.Opt.topic, int32(i), p.Opt.ConnectTimeout)
+		client := &Client{
+			Partition:           int32(i),
+			OperationMaxRedoNum: int32(p.Opt.OperationMaxRedoNum),
+		}
+		args := &pb.ConnectArgs{
+			Name:         p.Opt.name,
+			Url:          cliUrl,
+			Redo:         0,
+			Topic:        p.Opt.topic,
+			Partition:    int32(i),
+			Type:         PartPuber,
+			PartitionNum: p.Opt.partitionNum,
+			PubMode:      int32(p.Opt.mode),
+			Timeout:      int32(p.Opt.ConnectTimeout),
+			Compression:  int32(p.Opt.compression),
+		}
+		name, err := client.Connect(p.Opt.srvUrl, args)
 		if err != nil {
 			if derr := p.disconnect(); derr != nil {
 				return err
@@ -66,8 +90,10 @@ func (p *PartPublisher) Connect() error {
 			return err
 		}
 		p.clients[name] = client
-		p.asyncSends[name].AsyncSendQueue = queue.New()
-		p.asyncSends[name].asyncSendCh = make(chan bool, p.Opt.AsyncMaxSendBufSize)
+		p.asyncSends[name] = &AsyncSend{
+			AsyncSendQueue: queue.New(),
+			asyncSendCh:    make(chan bool, p.Opt.AsyncMaxSendBufSize),
+		}
 		p.partition2fullname[i] = name
 	}
 
@@ -79,33 +105,35 @@ func (p *PartPublisher) Connect() error {
 }
 
 func (p *PartPublisher) Publish(m *Msg) error {
+	payload, err := Compress(p.Opt.compression, m.Data)
+	if err != nil {
+		return err
+	}
+	part, err := p.msg2part(m)
+	if err != nil {
+		return err
+	}
 	args := &pb.PublishArgs{
-		Topic:   m.Topic,
-		Mid:     nrand(),
-		Payload: string(m.Data),
-		Redo:    0,
-	}
-
-	switch m.Partition {
-	case -1:
-		args.Partition = int32(p.msg2part(args.Mid))
-		// todo: update mid... to msg ?
-	default:
-		if m.Partition > int(p.Opt.partitionNum) || m.Partition < 0 {
-			return errors.New(fmt.Sprintf("topic/partition %v does not exist", m.Partition))
-		}
-		args.Partition = int32(m.Partition)
+		Name:         p.partition2fullname[part],
+		Topic:        m.Topic,
+		Partition:    int32(part),
+		Mid:          nrand(),
+		Key:          m.Key,
+		Payload:      payload,
+		Redo:         0,
+		Compression:  int32(p.Opt.compression),
+		Crc:          Checksum(payload),
+		Properties:   m.Properties,
+		EventTime:    m.EventTime,
+		DeliverAt:    m.deliverAt(),
+		Ttl:          m.TTL.Milliseconds(),
+		ProducerName: p.Opt.name,
 	}
 
-	for k, v := range p.clients {
-		if v.Partition == args.Partition {
-			args.Name = k
-		}
-	}
 	if args.Name == "" {
 		return errors.New(fmt.Sprintf("connection with topic/partition %v does not exist", args.Partition))
 	}
-	_, err := p.clients[args.Name].Push2serverWithRedo(args, p.Opt.OperationTimeout)
+	_, err = p.clients[args.Name].publish(args, p.Opt.OperationTimeout)
 	if err != nil {
 		return err
 	}
@@ -113,6 +141,50 @@ func (p *PartPublisher) Publish(m *Msg) error {
 	return nil
 }
 
+// PublishBatch publishes ms with one request per partition, each batch
+// compressed as a whole. The order of ms is kept within a partition.
+func (p *PartPublisher) PublishBatch(ms []*Msg) error {
+	var parts []int
+	batches := make(map[int][]*Msg)
+	for _, m := range ms {
+		part, err := p.msg2part(m)
+		if err != nil {
+			return err
+		}
+		if _, ok := batches[part]; !ok {
+			parts = append(parts, part)
+		}
+		batches[part] = append(batches[part], m)
+	}
+
+	for _, part := range parts {
+		batch := batches[part]
+		payload, err := newBatch(p.Opt.compression, batch)
+		if err != nil {
+			return err
+		}
+		args := &pb.PublishArgs{
+			Name:         p.partition2fullname[part],
+			Topic:        batch[0].Topic,
+			Partition:    int32(part),
+			Mid:          nrand(),
+			Payload:      payload,
+			Redo:         0,
+			Compression:  int32(p.Opt.compression),
+			Crc:          Checksum(payload),
+			ProducerName: p.Opt.name,
+			BatchSize:    int32(len(batch)),
+		}
+		if args.Name == "" {
+			return errors.New(fmt.Sprintf("connection with topic/partition %v does not exist", args.Partition))
+		}
+		if _, err := p.clients[args.Name].publish(args, p.Opt.OperationTimeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p *PartPublisher) disconnect() error {
 	for _, v := range p.clients {
 		if err := v.DisConnect(); err != nil {
@@ -124,16 +196,23 @@ func (p *PartPublisher) disconnect() error {
 
 // callback ?
 func (p *PartPublisher) AsyncPublish(m *Msg) error {
-	if m.Partition > int(p.Opt.partitionNum) || m.Partition < -2 || m.Partition == 0 {
-		return errors.New(fmt.Sprintf("topic/partition %v does not exist", m.Partition))
+	part, err := p.msg2part(m)
+	if err != nil {
+		return err
 	}
-
-	name := p.partition2fullname[m.Partition]
-	if p.asyncSends[name].AsyncSendQueue.Size() >= p.Opt.AsyncMaxSendBufSize {
+	name := p.partition2fullname[part]
+	as, ok := p.asyncSends[name]
+	if !ok {
+		return errors.New(fmt.Sprintf("connection with topic/partition %v does not exist", part))
+	}
+	if as.AsyncSendQueue.Size() >= p.Opt.AsyncMaxSendBufSize {
 		return errors.New("AsyncMaxSendBufSize is full")
 	}
-	p.asyncSends[name].AsyncSendQueue.Push(m)
-	p.asyncSends[name].asyncSendCh <- true
+	// routed once, the queue of the partition keeps the order
+	routed := *m
+	routed.Partition = part
+	as.AsyncSendQueue.Push(&routed)
+	as.asyncSendCh <- true
 	return nil
 }
 
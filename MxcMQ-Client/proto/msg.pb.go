@@ -194,6 +194,10 @@ type RequestAllocArgs struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Topic     string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Partition int32  `protobuf:"varint,2,opt,name=partition,proto3" json:"partition,omitempty"`
+	Redo      int32  `protobuf:"varint,3,opt,name=redo,proto3" json:"redo,omitempty"`
 }
 
 func (x *RequestAllocArgs) Reset() {
@@ -228,6 +232,27 @@ func (*RequestAllocArgs) Descriptor() ([]byte, []int) {
 	return file_msg_proto_rawDescGZIP(), []int{2}
 }
 
+func (x *RequestAllocArgs) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *RequestAllocArgs) GetPartition() int32 {
+	if x != nil {
+		return x.Partition
+	}
+	return 0
+}
+
+func (x *RequestAllocArgs) GetRedo() int32 {
+	if x != nil {
+		return x.Redo
+	}
+	return 0
+}
+
 type RequestAllocReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -280,13 +305,18 @@ type ConnectArgs struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Url       string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
-	Redo      int32  `protobuf:"varint,3,opt,name=redo,proto3" json:"redo,omitempty"`
-	Topic     string `protobuf:"bytes,4,opt,name=topic,proto3" json:"topic,omitempty"`
-	Partition int32  `protobuf:"varint,5,opt,name=partition,proto3" json:"partition,omitempty"`
-	Type      int32  `protobuf:"varint,6,opt,name=type,proto3" json:"type,omitempty"`
-	Timeout   int32  `protobuf:"varint,7,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Redo          int32  `protobuf:"varint,3,opt,name=redo,proto3" json:"redo,omitempty"`
+	Topic         string `protobuf:"bytes,4,opt,name=topic,proto3" json:"topic,omitempty"`
+	Partition     int32  `protobuf:"varint,5,opt,name=partition,proto3" json:"partition,omitempty"`
+	Type          int32  `protobuf:"varint,6,opt,name=type,proto3" json:"type,omitempty"`
+	Timeout       int32  `protobuf:"varint,7,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	PubMode       int32  `protobuf:"varint,8,opt,name=pubMode,proto3" json:"pubMode,omitempty"`
+	PartitionNum  int32  `protobuf:"varint,9,opt,name=partitionNum,proto3" json:"partitionNum,omitempty"`
+	Id            int64  `protobuf:"varint,10,opt,name=id,proto3" json:"id,omitempty"`
+	Compression   int32  `protobuf:"varint,11,opt,name=compression,proto3" json:"compression,omitempty"`
+	BinaryPayload bool   `protobuf:"varint,12,opt,name=binaryPayload,proto3" json:"binaryPayload,omitempty"`
 }
 
 func (x *ConnectArgs) Reset() {
@@ -370,12 +400,49 @@ func (x *ConnectArgs) GetTimeout() int32 {
 	return 0
 }
 
+func (x *ConnectArgs) GetPubMode() int32 {
+	if x != nil {
+		return x.PubMode
+	}
+	return 0
+}
+
+func (x *ConnectArgs) GetPartitionNum() int32 {
+	if x != nil {
+		return x.PartitionNum
+	}
+	return 0
+}
+
+func (x *ConnectArgs) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ConnectArgs) GetCompression() int32 {
+	if x != nil {
+		return x.Compression
+	}
+	return 0
+}
+
+func (x *ConnectArgs) GetBinaryPayload() bool {
+	if x != nil {
+		return x.BinaryPayload
+	}
+	return false
+}
+
 type ConnectReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name           string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Compression    int32  `protobuf:"varint,2,opt,name=compression,proto3" json:"compression,omitempty"`
+	LastSequenceId int64  `protobuf:"varint,3,opt,name=lastSequenceId,proto3" json:"lastSequenceId,omitempty"`
 }
 
 func (x *ConnectReply) Reset() {
@@ -417,6 +484,20 @@ func (x *ConnectReply) GetName() string {
 	return ""
 }
 
+func (x *ConnectReply) GetCompression() int32 {
+	if x != nil {
+		return x.Compression
+	}
+	return 0
+}
+
+func (x *ConnectReply) GetLastSequenceId() int64 {
+	if x != nil {
+		return x.LastSequenceId
+	}
+	return 0
+}
+
 type SubscribeArgs struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -430,6 +511,10 @@ type SubscribeArgs struct {
 	Key          int32                 `protobuf:"varint,6,opt,name=key,proto3" json:"key,omitempty"`
 	SubOffset    uint64                `protobuf:"varint,7,opt,name=subOffset,proto3" json:"subOffset,omitempty"`
 	Redo         int32                 `protobuf:"varint,8,opt,name=redo,proto3" json:"redo,omitempty"`
+	Id           int64                 `protobuf:"varint,9,opt,name=id,proto3" json:"id,omitempty"`
+	DeadLetter   *DeadLetterPolicy     `protobuf:"bytes,10,opt,name=deadLetter,proto3" json:"deadLetter,omitempty"`
+	NackBackoff  *NackBackoff          `protobuf:"bytes,11,opt,name=nackBackoff,proto3" json:"nackBackoff,omitempty"`
+	AckTimeout   int64                 `protobuf:"varint,12,opt,name=ackTimeout,proto3" json:"ackTimeout,omitempty"`
 }
 
 func (x *SubscribeArgs) Reset() {
@@ -520,6 +605,144 @@ func (x *SubscribeArgs) GetRedo() int32 {
 	return 0
 }
 
+func (x *SubscribeArgs) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SubscribeArgs) GetDeadLetter() *DeadLetterPolicy {
+	if x != nil {
+		return x.DeadLetter
+	}
+	return nil
+}
+
+func (x *SubscribeArgs) GetNackBackoff() *NackBackoff {
+	if x != nil {
+		return x.NackBackoff
+	}
+	return nil
+}
+
+func (x *SubscribeArgs) GetAckTimeout() int64 {
+	if x != nil {
+		return x.AckTimeout
+	}
+	return 0
+}
+
+type DeadLetterPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxDeliveries int32  `protobuf:"varint,1,opt,name=maxDeliveries,proto3" json:"maxDeliveries,omitempty"`
+	Topic         string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+}
+
+func (x *DeadLetterPolicy) Reset() {
+	*x = DeadLetterPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeadLetterPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetterPolicy) ProtoMessage() {}
+
+func (x *DeadLetterPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetterPolicy.ProtoReflect.Descriptor instead.
+func (*DeadLetterPolicy) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeadLetterPolicy) GetMaxDeliveries() int32 {
+	if x != nil {
+		return x.MaxDeliveries
+	}
+	return 0
+}
+
+func (x *DeadLetterPolicy) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+type NackBackoff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinDelay int64 `protobuf:"varint,1,opt,name=minDelay,proto3" json:"minDelay,omitempty"`
+	MaxDelay int64 `protobuf:"varint,2,opt,name=maxDelay,proto3" json:"maxDelay,omitempty"`
+}
+
+func (x *NackBackoff) Reset() {
+	*x = NackBackoff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NackBackoff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NackBackoff) ProtoMessage() {}
+
+func (x *NackBackoff) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NackBackoff.ProtoReflect.Descriptor instead.
+func (*NackBackoff) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NackBackoff) GetMinDelay() int64 {
+	if x != nil {
+		return x.MinDelay
+	}
+	return 0
+}
+
+func (x *NackBackoff) GetMaxDelay() int64 {
+	if x != nil {
+		return x.MaxDelay
+	}
+	return 0
+}
+
 type SubscribeReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -531,7 +754,7 @@ type SubscribeReply struct {
 func (x *SubscribeReply) Reset() {
 	*x = SubscribeReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[7]
+		mi := &file_msg_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -544,7 +767,7 @@ func (x *SubscribeReply) String() string {
 func (*SubscribeReply) ProtoMessage() {}
 
 func (x *SubscribeReply) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[7]
+	mi := &file_msg_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -557,7 +780,7 @@ func (x *SubscribeReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeReply.ProtoReflect.Descriptor instead.
 func (*SubscribeReply) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{7}
+	return file_msg_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SubscribeReply) GetError() string {
@@ -584,7 +807,7 @@ type PullArgs struct {
 func (x *PullArgs) Reset() {
 	*x = PullArgs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[8]
+		mi := &file_msg_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -597,7 +820,7 @@ func (x *PullArgs) String() string {
 func (*PullArgs) ProtoMessage() {}
 
 func (x *PullArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[8]
+	mi := &file_msg_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -610,7 +833,7 @@ func (x *PullArgs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PullArgs.ProtoReflect.Descriptor instead.
 func (*PullArgs) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{8}
+	return file_msg_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *PullArgs) GetName() string {
@@ -673,7 +896,7 @@ type PullReply struct {
 func (x *PullReply) Reset() {
 	*x = PullReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[9]
+		mi := &file_msg_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -686,7 +909,7 @@ func (x *PullReply) String() string {
 func (*PullReply) ProtoMessage() {}
 
 func (x *PullReply) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[9]
+	mi := &file_msg_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -699,7 +922,7 @@ func (x *PullReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PullReply.ProtoReflect.Descriptor instead.
 func (*PullReply) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{9}
+	return file_msg_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PullReply) GetError() string {
@@ -724,7 +947,7 @@ type UnSubscribeArgs struct {
 func (x *UnSubscribeArgs) Reset() {
 	*x = UnSubscribeArgs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[10]
+		mi := &file_msg_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -737,7 +960,7 @@ func (x *UnSubscribeArgs) String() string {
 func (*UnSubscribeArgs) ProtoMessage() {}
 
 func (x *UnSubscribeArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[10]
+	mi := &file_msg_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -750,7 +973,7 @@ func (x *UnSubscribeArgs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnSubscribeArgs.ProtoReflect.Descriptor instead.
 func (*UnSubscribeArgs) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{10}
+	return file_msg_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *UnSubscribeArgs) GetName() string {
@@ -797,7 +1020,7 @@ type UnSubscribeReply struct {
 func (x *UnSubscribeReply) Reset() {
 	*x = UnSubscribeReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[11]
+		mi := &file_msg_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -810,7 +1033,7 @@ func (x *UnSubscribeReply) String() string {
 func (*UnSubscribeReply) ProtoMessage() {}
 
 func (x *UnSubscribeReply) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[11]
+	mi := &file_msg_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -823,7 +1046,7 @@ func (x *UnSubscribeReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnSubscribeReply.ProtoReflect.Descriptor instead.
 func (*UnSubscribeReply) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{11}
+	return file_msg_proto_rawDescGZIP(), []int{13}
 }
 
 type PublishArgs struct {
@@ -831,19 +1054,29 @@ type PublishArgs struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Topic     string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
-	Partition int32  `protobuf:"varint,3,opt,name=partition,proto3" json:"partition,omitempty"`
-	Mid       int64  `protobuf:"varint,4,opt,name=mid,proto3" json:"mid,omitempty"`
-	Msid      int64  `protobuf:"varint,5,opt,name=msid,proto3" json:"msid,omitempty"`
-	Payload   string `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
-	Redo      int32  `protobuf:"varint,7,opt,name=redo,proto3" json:"redo,omitempty"`
+	Name         string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Topic        string            `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Partition    int32             `protobuf:"varint,3,opt,name=partition,proto3" json:"partition,omitempty"`
+	Mid          int64             `protobuf:"varint,4,opt,name=mid,proto3" json:"mid,omitempty"`
+	Msid         int64             `protobuf:"varint,5,opt,name=msid,proto3" json:"msid,omitempty"`
+	Payload      []byte            `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+	Redo         int32             `protobuf:"varint,7,opt,name=redo,proto3" json:"redo,omitempty"`
+	Compression  int32             `protobuf:"varint,8,opt,name=compression,proto3" json:"compression,omitempty"`
+	Key          string            `protobuf:"bytes,9,opt,name=key,proto3" json:"key,omitempty"`
+	Crc          uint32            `protobuf:"varint,10,opt,name=crc,proto3" json:"crc,omitempty"`
+	Properties   map[string]string `protobuf:"bytes,11,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	EventTime    int64             `protobuf:"varint,12,opt,name=eventTime,proto3" json:"eventTime,omitempty"`
+	ProducerName string            `protobuf:"bytes,13,opt,name=producerName,proto3" json:"producerName,omitempty"`
+	DeliverAt    int64             `protobuf:"varint,14,opt,name=deliverAt,proto3" json:"deliverAt,omitempty"`
+	Ttl          int64             `protobuf:"varint,15,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	SequenceId   int64             `protobuf:"varint,16,opt,name=sequenceId,proto3" json:"sequenceId,omitempty"`
+	BatchSize    int32             `protobuf:"varint,17,opt,name=batchSize,proto3" json:"batchSize,omitempty"`
 }
 
 func (x *PublishArgs) Reset() {
 	*x = PublishArgs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[12]
+		mi := &file_msg_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -856,7 +1089,7 @@ func (x *PublishArgs) String() string {
 func (*PublishArgs) ProtoMessage() {}
 
 func (x *PublishArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[12]
+	mi := &file_msg_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -869,7 +1102,7 @@ func (x *PublishArgs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublishArgs.ProtoReflect.Descriptor instead.
 func (*PublishArgs) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{12}
+	return file_msg_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *PublishArgs) GetName() string {
@@ -907,11 +1140,11 @@ func (x *PublishArgs) GetMsid() int64 {
 	return 0
 }
 
-func (x *PublishArgs) GetPayload() string {
+func (x *PublishArgs) GetPayload() []byte {
 	if x != nil {
 		return x.Payload
 	}
-	return ""
+	return nil
 }
 
 func (x *PublishArgs) GetRedo() int32 {
@@ -921,62 +1154,292 @@ func (x *PublishArgs) GetRedo() int32 {
 	return 0
 }
 
-type PublishReply struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *PublishArgs) GetCompression() int32 {
+	if x != nil {
+		return x.Compression
+	}
+	return 0
 }
 
-func (x *PublishReply) Reset() {
-	*x = PublishReply{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[13]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *PublishArgs) GetKey() string {
+	if x != nil {
+		return x.Key
 	}
+	return ""
 }
 
-func (x *PublishReply) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *PublishArgs) GetCrc() uint32 {
+	if x != nil {
+		return x.Crc
+	}
+	return 0
 }
 
-func (*PublishReply) ProtoMessage() {}
+func (x *PublishArgs) GetProperties() map[string]string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
 
-func (x *PublishReply) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[13]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *PublishArgs) GetEventTime() int64 {
+	if x != nil {
+		return x.EventTime
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use PublishReply.ProtoReflect.Descriptor instead.
-func (*PublishReply) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{13}
+func (x *PublishArgs) GetProducerName() string {
+	if x != nil {
+		return x.ProducerName
+	}
+	return ""
 }
 
-type MsgArgs struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *PublishArgs) GetDeliverAt() int64 {
+	if x != nil {
+		return x.DeliverAt
+	}
+	return 0
+}
 
-	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Topic     string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
-	Partition int32  `protobuf:"varint,3,opt,name=partition,proto3" json:"partition,omitempty"`
-	Mid       int64  `protobuf:"varint,4,opt,name=mid,proto3" json:"mid,omitempty"`
-	Msid      int64  `protobuf:"varint,5,opt,name=msid,proto3" json:"msid,omitempty"`
-	Payload   string `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
-	Redo      int32  `protobuf:"varint,7,opt,name=redo,proto3" json:"redo,omitempty"`
+func (x *PublishArgs) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
 }
 
-func (x *MsgArgs) Reset() {
+func (x *PublishArgs) GetSequenceId() int64 {
+	if x != nil {
+		return x.SequenceId
+	}
+	return 0
+}
+
+func (x *PublishArgs) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+type Batch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*BatchEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *Batch) Reset() {
+	*x = Batch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Batch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Batch) ProtoMessage() {}
+
+func (x *Batch) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Batch.ProtoReflect.Descriptor instead.
+func (*Batch) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Batch) GetEntries() []*BatchEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type BatchEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key        string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Payload    []byte            `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Properties map[string]string `protobuf:"bytes,3,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	EventTime  int64             `protobuf:"varint,4,opt,name=eventTime,proto3" json:"eventTime,omitempty"`
+	DeliverAt  int64             `protobuf:"varint,5,opt,name=deliverAt,proto3" json:"deliverAt,omitempty"`
+	Ttl        int64             `protobuf:"varint,6,opt,name=ttl,proto3" json:"ttl,omitempty"`
+}
+
+func (x *BatchEntry) Reset() {
+	*x = BatchEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchEntry) ProtoMessage() {}
+
+func (x *BatchEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchEntry.ProtoReflect.Descriptor instead.
+func (*BatchEntry) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *BatchEntry) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *BatchEntry) GetProperties() map[string]string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *BatchEntry) GetEventTime() int64 {
+	if x != nil {
+		return x.EventTime
+	}
+	return 0
+}
+
+func (x *BatchEntry) GetDeliverAt() int64 {
+	if x != nil {
+		return x.DeliverAt
+	}
+	return 0
+}
+
+func (x *BatchEntry) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+type PublishReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msid      uint64 `protobuf:"varint,1,opt,name=msid,proto3" json:"msid,omitempty"`
+	Duplicate bool   `protobuf:"varint,2,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+}
+
+func (x *PublishReply) Reset() {
+	*x = PublishReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublishReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishReply) ProtoMessage() {}
+
+func (x *PublishReply) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishReply.ProtoReflect.Descriptor instead.
+func (*PublishReply) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PublishReply) GetMsid() uint64 {
+	if x != nil {
+		return x.Msid
+	}
+	return 0
+}
+
+func (x *PublishReply) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+type MsgArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name            string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Topic           string            `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Partition       int32             `protobuf:"varint,3,opt,name=partition,proto3" json:"partition,omitempty"`
+	Mid             int64             `protobuf:"varint,4,opt,name=mid,proto3" json:"mid,omitempty"`
+	Msid            uint64            `protobuf:"varint,5,opt,name=msid,proto3" json:"msid,omitempty"`
+	Payload         []byte            `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+	Redo            int32             `protobuf:"varint,7,opt,name=redo,proto3" json:"redo,omitempty"`
+	Suber           string            `protobuf:"bytes,8,opt,name=suber,proto3" json:"suber,omitempty"`
+	Compression     int32             `protobuf:"varint,9,opt,name=compression,proto3" json:"compression,omitempty"`
+	Key             string            `protobuf:"bytes,10,opt,name=key,proto3" json:"key,omitempty"`
+	Crc             uint32            `protobuf:"varint,11,opt,name=crc,proto3" json:"crc,omitempty"`
+	Properties      map[string]string `protobuf:"bytes,12,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	EventTime       int64             `protobuf:"varint,13,opt,name=eventTime,proto3" json:"eventTime,omitempty"`
+	PublishTime     int64             `protobuf:"varint,14,opt,name=publishTime,proto3" json:"publishTime,omitempty"`
+	ProducerName    string            `protobuf:"bytes,15,opt,name=producerName,proto3" json:"producerName,omitempty"`
+	RedeliveryCount int32             `protobuf:"varint,16,opt,name=redeliveryCount,proto3" json:"redeliveryCount,omitempty"`
+}
+
+func (x *MsgArgs) Reset() {
 	*x = MsgArgs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[14]
+		mi := &file_msg_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -989,7 +1452,7 @@ func (x *MsgArgs) String() string {
 func (*MsgArgs) ProtoMessage() {}
 
 func (x *MsgArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[14]
+	mi := &file_msg_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1002,7 +1465,7 @@ func (x *MsgArgs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgArgs.ProtoReflect.Descriptor instead.
 func (*MsgArgs) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{14}
+	return file_msg_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *MsgArgs) GetName() string {
@@ -1033,18 +1496,18 @@ func (x *MsgArgs) GetMid() int64 {
 	return 0
 }
 
-func (x *MsgArgs) GetMsid() int64 {
+func (x *MsgArgs) GetMsid() uint64 {
 	if x != nil {
 		return x.Msid
 	}
 	return 0
 }
 
-func (x *MsgArgs) GetPayload() string {
+func (x *MsgArgs) GetPayload() []byte {
 	if x != nil {
 		return x.Payload
 	}
-	return ""
+	return nil
 }
 
 func (x *MsgArgs) GetRedo() int32 {
@@ -1054,6 +1517,69 @@ func (x *MsgArgs) GetRedo() int32 {
 	return 0
 }
 
+func (x *MsgArgs) GetSuber() string {
+	if x != nil {
+		return x.Suber
+	}
+	return ""
+}
+
+func (x *MsgArgs) GetCompression() int32 {
+	if x != nil {
+		return x.Compression
+	}
+	return 0
+}
+
+func (x *MsgArgs) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MsgArgs) GetCrc() uint32 {
+	if x != nil {
+		return x.Crc
+	}
+	return 0
+}
+
+func (x *MsgArgs) GetProperties() map[string]string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *MsgArgs) GetEventTime() int64 {
+	if x != nil {
+		return x.EventTime
+	}
+	return 0
+}
+
+func (x *MsgArgs) GetPublishTime() int64 {
+	if x != nil {
+		return x.PublishTime
+	}
+	return 0
+}
+
+func (x *MsgArgs) GetProducerName() string {
+	if x != nil {
+		return x.ProducerName
+	}
+	return ""
+}
+
+func (x *MsgArgs) GetRedeliveryCount() int32 {
+	if x != nil {
+		return x.RedeliveryCount
+	}
+	return 0
+}
+
 type MsgReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1063,7 +1589,7 @@ type MsgReply struct {
 func (x *MsgReply) Reset() {
 	*x = MsgReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[15]
+		mi := &file_msg_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1076,7 +1602,7 @@ func (x *MsgReply) String() string {
 func (*MsgReply) ProtoMessage() {}
 
 func (x *MsgReply) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[15]
+	mi := &file_msg_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1089,7 +1615,7 @@ func (x *MsgReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgReply.ProtoReflect.Descriptor instead.
 func (*MsgReply) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{15}
+	return file_msg_proto_rawDescGZIP(), []int{19}
 }
 
 type MsgAckArgs struct {
@@ -1097,17 +1623,19 @@ type MsgAckArgs struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Topic        string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
-	Partition    int32  `protobuf:"varint,3,opt,name=partition,proto3" json:"partition,omitempty"`
-	Subscription string `protobuf:"bytes,4,opt,name=subscription,proto3" json:"subscription,omitempty"`
-	AckOffset    uint64 `protobuf:"varint,5,opt,name=ackOffset,proto3" json:"ackOffset,omitempty"`
+	Name         string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Topic        string   `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Partition    int32    `protobuf:"varint,3,opt,name=partition,proto3" json:"partition,omitempty"`
+	Subscription string   `protobuf:"bytes,4,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	AckOffset    uint64   `protobuf:"varint,5,opt,name=ackOffset,proto3" json:"ackOffset,omitempty"`
+	Msids        []uint64 `protobuf:"varint,6,rep,packed,name=msids,proto3" json:"msids,omitempty"`
+	Redo         int32    `protobuf:"varint,7,opt,name=redo,proto3" json:"redo,omitempty"`
 }
 
 func (x *MsgAckArgs) Reset() {
 	*x = MsgAckArgs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[16]
+		mi := &file_msg_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1120,7 +1648,7 @@ func (x *MsgAckArgs) String() string {
 func (*MsgAckArgs) ProtoMessage() {}
 
 func (x *MsgAckArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[16]
+	mi := &file_msg_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1133,7 +1661,7 @@ func (x *MsgAckArgs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgAckArgs.ProtoReflect.Descriptor instead.
 func (*MsgAckArgs) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{16}
+	return file_msg_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *MsgAckArgs) GetName() string {
@@ -1171,6 +1699,20 @@ func (x *MsgAckArgs) GetAckOffset() uint64 {
 	return 0
 }
 
+func (x *MsgAckArgs) GetMsids() []uint64 {
+	if x != nil {
+		return x.Msids
+	}
+	return nil
+}
+
+func (x *MsgAckArgs) GetRedo() int32 {
+	if x != nil {
+		return x.Redo
+	}
+	return 0
+}
+
 type MsgAckReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1180,7 +1722,7 @@ type MsgAckReply struct {
 func (x *MsgAckReply) Reset() {
 	*x = MsgAckReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[17]
+		mi := &file_msg_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1193,7 +1735,7 @@ func (x *MsgAckReply) String() string {
 func (*MsgAckReply) ProtoMessage() {}
 
 func (x *MsgAckReply) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[17]
+	mi := &file_msg_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1206,32 +1748,157 @@ func (x *MsgAckReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgAckReply.ProtoReflect.Descriptor instead.
 func (*MsgAckReply) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{17}
+	return file_msg_proto_rawDescGZIP(), []int{21}
 }
 
-type Ack2PuberArgs struct {
+type NackArgs struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Name         string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Topic        string   `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Partition    int32    `protobuf:"varint,3,opt,name=partition,proto3" json:"partition,omitempty"`
+	Subscription string   `protobuf:"bytes,4,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	Msids        []uint64 `protobuf:"varint,5,rep,packed,name=msids,proto3" json:"msids,omitempty"`
+	Redo         int32    `protobuf:"varint,6,opt,name=redo,proto3" json:"redo,omitempty"`
 }
 
-func (x *Ack2PuberArgs) Reset() {
-	*x = Ack2PuberArgs{}
+func (x *NackArgs) Reset() {
+	*x = NackArgs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[18]
+		mi := &file_msg_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Ack2PuberArgs) String() string {
+func (x *NackArgs) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Ack2PuberArgs) ProtoMessage() {}
+func (*NackArgs) ProtoMessage() {}
 
-func (x *Ack2PuberArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[18]
+func (x *NackArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NackArgs.ProtoReflect.Descriptor instead.
+func (*NackArgs) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *NackArgs) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NackArgs) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *NackArgs) GetPartition() int32 {
+	if x != nil {
+		return x.Partition
+	}
+	return 0
+}
+
+func (x *NackArgs) GetSubscription() string {
+	if x != nil {
+		return x.Subscription
+	}
+	return ""
+}
+
+func (x *NackArgs) GetMsids() []uint64 {
+	if x != nil {
+		return x.Msids
+	}
+	return nil
+}
+
+func (x *NackArgs) GetRedo() int32 {
+	if x != nil {
+		return x.Redo
+	}
+	return 0
+}
+
+type NackReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NackReply) Reset() {
+	*x = NackReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NackReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NackReply) ProtoMessage() {}
+
+func (x *NackReply) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NackReply.ProtoReflect.Descriptor instead.
+func (*NackReply) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{23}
+}
+
+type Ack2PuberArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Ack2PuberArgs) Reset() {
+	*x = Ack2PuberArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ack2PuberArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ack2PuberArgs) ProtoMessage() {}
+
+func (x *Ack2PuberArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1244,32 +1911,1099 @@ func (x *Ack2PuberArgs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Ack2PuberArgs.ProtoReflect.Descriptor instead.
 func (*Ack2PuberArgs) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{18}
+	return file_msg_proto_rawDescGZIP(), []int{24}
+}
+
+type Ack2PuberReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Ack2PuberReply) Reset() {
+	*x = Ack2PuberReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ack2PuberReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ack2PuberReply) ProtoMessage() {}
+
+func (x *Ack2PuberReply) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ack2PuberReply.ProtoReflect.Descriptor instead.
+func (*Ack2PuberReply) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{25}
+}
+
+type GetTopicInfoArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Topic string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Redo  int32  `protobuf:"varint,3,opt,name=redo,proto3" json:"redo,omitempty"`
+}
+
+func (x *GetTopicInfoArgs) Reset() {
+	*x = GetTopicInfoArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTopicInfoArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopicInfoArgs) ProtoMessage() {}
+
+func (x *GetTopicInfoArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopicInfoArgs.ProtoReflect.Descriptor instead.
+func (*GetTopicInfoArgs) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetTopicInfoArgs) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetTopicInfoArgs) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *GetTopicInfoArgs) GetRedo() int32 {
+	if x != nil {
+		return x.Redo
+	}
+	return 0
+}
+
+type GetTopicInfoReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PartitionNum int32  `protobuf:"varint,2,opt,name=partitionNum,proto3" json:"partitionNum,omitempty"`
+}
+
+func (x *GetTopicInfoReply) Reset() {
+	*x = GetTopicInfoReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTopicInfoReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopicInfoReply) ProtoMessage() {}
+
+func (x *GetTopicInfoReply) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopicInfoReply.ProtoReflect.Descriptor instead.
+func (*GetTopicInfoReply) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetTopicInfoReply) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetTopicInfoReply) GetPartitionNum() int32 {
+	if x != nil {
+		return x.PartitionNum
+	}
+	return 0
+}
+
+type RetentionPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxAge        int64  `protobuf:"varint,1,opt,name=maxAge,proto3" json:"maxAge,omitempty"`
+	MaxBytes      int64  `protobuf:"varint,2,opt,name=maxBytes,proto3" json:"maxBytes,omitempty"`
+	MaxMsgs       uint64 `protobuf:"varint,3,opt,name=maxMsgs,proto3" json:"maxMsgs,omitempty"`
+	DeleteUnacked bool   `protobuf:"varint,4,opt,name=deleteUnacked,proto3" json:"deleteUnacked,omitempty"`
+	DeleteAcked   bool   `protobuf:"varint,5,opt,name=deleteAcked,proto3" json:"deleteAcked,omitempty"`
+	Compact       bool   `protobuf:"varint,6,opt,name=compact,proto3" json:"compact,omitempty"`
+}
+
+func (x *RetentionPolicy) Reset() {
+	*x = RetentionPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RetentionPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetentionPolicy) ProtoMessage() {}
+
+func (x *RetentionPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetentionPolicy.ProtoReflect.Descriptor instead.
+func (*RetentionPolicy) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RetentionPolicy) GetMaxAge() int64 {
+	if x != nil {
+		return x.MaxAge
+	}
+	return 0
+}
+
+func (x *RetentionPolicy) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *RetentionPolicy) GetMaxMsgs() uint64 {
+	if x != nil {
+		return x.MaxMsgs
+	}
+	return 0
+}
+
+func (x *RetentionPolicy) GetDeleteUnacked() bool {
+	if x != nil {
+		return x.DeleteUnacked
+	}
+	return false
+}
+
+func (x *RetentionPolicy) GetDeleteAcked() bool {
+	if x != nil {
+		return x.DeleteAcked
+	}
+	return false
+}
+
+func (x *RetentionPolicy) GetCompact() bool {
+	if x != nil {
+		return x.Compact
+	}
+	return false
+}
+
+type OffloadPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxHotMsgs uint64 `protobuf:"varint,1,opt,name=maxHotMsgs,proto3" json:"maxHotMsgs,omitempty"`
+}
+
+func (x *OffloadPolicy) Reset() {
+	*x = OffloadPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OffloadPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OffloadPolicy) ProtoMessage() {}
+
+func (x *OffloadPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OffloadPolicy.ProtoReflect.Descriptor instead.
+func (*OffloadPolicy) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *OffloadPolicy) GetMaxHotMsgs() uint64 {
+	if x != nil {
+		return x.MaxHotMsgs
+	}
+	return 0
+}
+
+type OffloadRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start uint64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   uint64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *OffloadRange) Reset() {
+	*x = OffloadRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OffloadRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OffloadRange) ProtoMessage() {}
+
+func (x *OffloadRange) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OffloadRange.ProtoReflect.Descriptor instead.
+func (*OffloadRange) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *OffloadRange) GetStart() uint64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *OffloadRange) GetEnd() uint64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type PartitionStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Partition     int32           `protobuf:"varint,1,opt,name=partition,proto3" json:"partition,omitempty"`
+	Mnum          uint64          `protobuf:"varint,2,opt,name=mnum,proto3" json:"mnum,omitempty"`
+	AckOffset     uint64          `protobuf:"varint,3,opt,name=ackOffset,proto3" json:"ackOffset,omitempty"`
+	PushOffset    uint64          `protobuf:"varint,4,opt,name=pushOffset,proto3" json:"pushOffset,omitempty"`
+	DeleteOffset  uint64          `protobuf:"varint,5,opt,name=deleteOffset,proto3" json:"deleteOffset,omitempty"`
+	Size          int64           `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	CompactOffset uint64          `protobuf:"varint,7,opt,name=compactOffset,proto3" json:"compactOffset,omitempty"`
+	Offloaded     []*OffloadRange `protobuf:"bytes,8,rep,name=offloaded,proto3" json:"offloaded,omitempty"`
+}
+
+func (x *PartitionStats) Reset() {
+	*x = PartitionStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PartitionStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartitionStats) ProtoMessage() {}
+
+func (x *PartitionStats) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartitionStats.ProtoReflect.Descriptor instead.
+func (*PartitionStats) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PartitionStats) GetPartition() int32 {
+	if x != nil {
+		return x.Partition
+	}
+	return 0
+}
+
+func (x *PartitionStats) GetMnum() uint64 {
+	if x != nil {
+		return x.Mnum
+	}
+	return 0
+}
+
+func (x *PartitionStats) GetAckOffset() uint64 {
+	if x != nil {
+		return x.AckOffset
+	}
+	return 0
+}
+
+func (x *PartitionStats) GetPushOffset() uint64 {
+	if x != nil {
+		return x.PushOffset
+	}
+	return 0
+}
+
+func (x *PartitionStats) GetDeleteOffset() uint64 {
+	if x != nil {
+		return x.DeleteOffset
+	}
+	return 0
+}
+
+func (x *PartitionStats) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *PartitionStats) GetCompactOffset() uint64 {
+	if x != nil {
+		return x.CompactOffset
+	}
+	return 0
+}
+
+func (x *PartitionStats) GetOffloaded() []*OffloadRange {
+	if x != nil {
+		return x.Offloaded
+	}
+	return nil
+}
+
+type GetTopicStatsArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Topic string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Redo  int32  `protobuf:"varint,3,opt,name=redo,proto3" json:"redo,omitempty"`
+}
+
+func (x *GetTopicStatsArgs) Reset() {
+	*x = GetTopicStatsArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTopicStatsArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopicStatsArgs) ProtoMessage() {}
+
+func (x *GetTopicStatsArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopicStatsArgs.ProtoReflect.Descriptor instead.
+func (*GetTopicStatsArgs) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetTopicStatsArgs) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetTopicStatsArgs) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *GetTopicStatsArgs) GetRedo() int32 {
+	if x != nil {
+		return x.Redo
+	}
+	return 0
+}
+
+type GetTopicStatsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PartitionNum int32             `protobuf:"varint,2,opt,name=partitionNum,proto3" json:"partitionNum,omitempty"`
+	Retention    *RetentionPolicy  `protobuf:"bytes,3,opt,name=retention,proto3" json:"retention,omitempty"`
+	Partitions   []*PartitionStats `protobuf:"bytes,4,rep,name=partitions,proto3" json:"partitions,omitempty"`
+	Offload      *OffloadPolicy    `protobuf:"bytes,5,opt,name=offload,proto3" json:"offload,omitempty"`
+	Quota        *QuotaPolicy      `protobuf:"bytes,6,opt,name=quota,proto3" json:"quota,omitempty"`
+	MessageTTL   int64             `protobuf:"varint,7,opt,name=messageTTL,proto3" json:"messageTTL,omitempty"`
+}
+
+func (x *GetTopicStatsReply) Reset() {
+	*x = GetTopicStatsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTopicStatsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopicStatsReply) ProtoMessage() {}
+
+func (x *GetTopicStatsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopicStatsReply.ProtoReflect.Descriptor instead.
+func (*GetTopicStatsReply) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetTopicStatsReply) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetTopicStatsReply) GetPartitionNum() int32 {
+	if x != nil {
+		return x.PartitionNum
+	}
+	return 0
+}
+
+func (x *GetTopicStatsReply) GetRetention() *RetentionPolicy {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *GetTopicStatsReply) GetPartitions() []*PartitionStats {
+	if x != nil {
+		return x.Partitions
+	}
+	return nil
+}
+
+func (x *GetTopicStatsReply) GetOffload() *OffloadPolicy {
+	if x != nil {
+		return x.Offload
+	}
+	return nil
+}
+
+func (x *GetTopicStatsReply) GetQuota() *QuotaPolicy {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *GetTopicStatsReply) GetMessageTTL() int64 {
+	if x != nil {
+		return x.MessageTTL
+	}
+	return 0
+}
+
+type SetRetentionArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Topic     string           `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Retention *RetentionPolicy `protobuf:"bytes,3,opt,name=retention,proto3" json:"retention,omitempty"`
+	Redo      int32            `protobuf:"varint,4,opt,name=redo,proto3" json:"redo,omitempty"`
+}
+
+func (x *SetRetentionArgs) Reset() {
+	*x = SetRetentionArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetRetentionArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRetentionArgs) ProtoMessage() {}
+
+func (x *SetRetentionArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRetentionArgs.ProtoReflect.Descriptor instead.
+func (*SetRetentionArgs) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SetRetentionArgs) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetRetentionArgs) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *SetRetentionArgs) GetRetention() *RetentionPolicy {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *SetRetentionArgs) GetRedo() int32 {
+	if x != nil {
+		return x.Redo
+	}
+	return 0
+}
+
+type SetRetentionReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetRetentionReply) Reset() {
+	*x = SetRetentionReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetRetentionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRetentionReply) ProtoMessage() {}
+
+func (x *SetRetentionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRetentionReply.ProtoReflect.Descriptor instead.
+func (*SetRetentionReply) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{35}
+}
+
+type SetOffloadArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Topic   string         `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Offload *OffloadPolicy `protobuf:"bytes,3,opt,name=offload,proto3" json:"offload,omitempty"`
+	Redo    int32          `protobuf:"varint,4,opt,name=redo,proto3" json:"redo,omitempty"`
+}
+
+func (x *SetOffloadArgs) Reset() {
+	*x = SetOffloadArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetOffloadArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOffloadArgs) ProtoMessage() {}
+
+func (x *SetOffloadArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOffloadArgs.ProtoReflect.Descriptor instead.
+func (*SetOffloadArgs) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SetOffloadArgs) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetOffloadArgs) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *SetOffloadArgs) GetOffload() *OffloadPolicy {
+	if x != nil {
+		return x.Offload
+	}
+	return nil
+}
+
+func (x *SetOffloadArgs) GetRedo() int32 {
+	if x != nil {
+		return x.Redo
+	}
+	return 0
+}
+
+type SetOffloadReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetOffloadReply) Reset() {
+	*x = SetOffloadReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetOffloadReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOffloadReply) ProtoMessage() {}
+
+func (x *SetOffloadReply) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOffloadReply.ProtoReflect.Descriptor instead.
+func (*SetOffloadReply) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{37}
+}
+
+type QuotaPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxBacklogMsgs  uint64 `protobuf:"varint,1,opt,name=maxBacklogMsgs,proto3" json:"maxBacklogMsgs,omitempty"`
+	MaxStorageBytes int64  `protobuf:"varint,2,opt,name=maxStorageBytes,proto3" json:"maxStorageBytes,omitempty"`
+	Action          int32  `protobuf:"varint,3,opt,name=action,proto3" json:"action,omitempty"`
+}
+
+func (x *QuotaPolicy) Reset() {
+	*x = QuotaPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuotaPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotaPolicy) ProtoMessage() {}
+
+func (x *QuotaPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotaPolicy.ProtoReflect.Descriptor instead.
+func (*QuotaPolicy) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *QuotaPolicy) GetMaxBacklogMsgs() uint64 {
+	if x != nil {
+		return x.MaxBacklogMsgs
+	}
+	return 0
+}
+
+func (x *QuotaPolicy) GetMaxStorageBytes() int64 {
+	if x != nil {
+		return x.MaxStorageBytes
+	}
+	return 0
+}
+
+func (x *QuotaPolicy) GetAction() int32 {
+	if x != nil {
+		return x.Action
+	}
+	return 0
+}
+
+type SetQuotaArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Topic string       `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Quota *QuotaPolicy `protobuf:"bytes,3,opt,name=quota,proto3" json:"quota,omitempty"`
+	Redo  int32        `protobuf:"varint,4,opt,name=redo,proto3" json:"redo,omitempty"`
+}
+
+func (x *SetQuotaArgs) Reset() {
+	*x = SetQuotaArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetQuotaArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetQuotaArgs) ProtoMessage() {}
+
+func (x *SetQuotaArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetQuotaArgs.ProtoReflect.Descriptor instead.
+func (*SetQuotaArgs) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SetQuotaArgs) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetQuotaArgs) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *SetQuotaArgs) GetQuota() *QuotaPolicy {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *SetQuotaArgs) GetRedo() int32 {
+	if x != nil {
+		return x.Redo
+	}
+	return 0
+}
+
+type SetQuotaReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetQuotaReply) Reset() {
+	*x = SetQuotaReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetQuotaReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetQuotaReply) ProtoMessage() {}
+
+func (x *SetQuotaReply) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetQuotaReply.ProtoReflect.Descriptor instead.
+func (*SetQuotaReply) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{40}
+}
+
+type SetMessageTTLArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Topic      string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	MessageTTL int64  `protobuf:"varint,3,opt,name=messageTTL,proto3" json:"messageTTL,omitempty"`
+	Redo       int32  `protobuf:"varint,4,opt,name=redo,proto3" json:"redo,omitempty"`
+}
+
+func (x *SetMessageTTLArgs) Reset() {
+	*x = SetMessageTTLArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetMessageTTLArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type Ack2PuberReply struct {
+func (*SetMessageTTLArgs) ProtoMessage() {}
+
+func (x *SetMessageTTLArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMessageTTLArgs.ProtoReflect.Descriptor instead.
+func (*SetMessageTTLArgs) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SetMessageTTLArgs) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetMessageTTLArgs) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *SetMessageTTLArgs) GetMessageTTL() int64 {
+	if x != nil {
+		return x.MessageTTL
+	}
+	return 0
+}
+
+func (x *SetMessageTTLArgs) GetRedo() int32 {
+	if x != nil {
+		return x.Redo
+	}
+	return 0
+}
+
+type SetMessageTTLReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *Ack2PuberReply) Reset() {
-	*x = Ack2PuberReply{}
+func (x *SetMessageTTLReply) Reset() {
+	*x = SetMessageTTLReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[19]
+		mi := &file_msg_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Ack2PuberReply) String() string {
+func (x *SetMessageTTLReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Ack2PuberReply) ProtoMessage() {}
+func (*SetMessageTTLReply) ProtoMessage() {}
 
-func (x *Ack2PuberReply) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[19]
+func (x *SetMessageTTLReply) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1280,38 +3014,37 @@ func (x *Ack2PuberReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Ack2PuberReply.ProtoReflect.Descriptor instead.
-func (*Ack2PuberReply) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use SetMessageTTLReply.ProtoReflect.Descriptor instead.
+func (*SetMessageTTLReply) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{42}
 }
 
-type GetTopicInfoArgs struct {
+type GetBrokerStatsArgs struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Topic string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
-	Redo  int32  `protobuf:"varint,3,opt,name=redo,proto3" json:"redo,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Redo int32  `protobuf:"varint,2,opt,name=redo,proto3" json:"redo,omitempty"`
 }
 
-func (x *GetTopicInfoArgs) Reset() {
-	*x = GetTopicInfoArgs{}
+func (x *GetBrokerStatsArgs) Reset() {
+	*x = GetBrokerStatsArgs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[20]
+		mi := &file_msg_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetTopicInfoArgs) String() string {
+func (x *GetBrokerStatsArgs) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTopicInfoArgs) ProtoMessage() {}
+func (*GetBrokerStatsArgs) ProtoMessage() {}
 
-func (x *GetTopicInfoArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[20]
+func (x *GetBrokerStatsArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1322,58 +3055,56 @@ func (x *GetTopicInfoArgs) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTopicInfoArgs.ProtoReflect.Descriptor instead.
-func (*GetTopicInfoArgs) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use GetBrokerStatsArgs.ProtoReflect.Descriptor instead.
+func (*GetBrokerStatsArgs) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *GetTopicInfoArgs) GetName() string {
+func (x *GetBrokerStatsArgs) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *GetTopicInfoArgs) GetTopic() string {
-	if x != nil {
-		return x.Topic
-	}
-	return ""
-}
-
-func (x *GetTopicInfoArgs) GetRedo() int32 {
+func (x *GetBrokerStatsArgs) GetRedo() int32 {
 	if x != nil {
 		return x.Redo
 	}
 	return 0
 }
 
-type GetTopicInfoReply struct {
+type GetBrokerStatsReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	PartitionNum int32  `protobuf:"varint,2,opt,name=partitionNum,proto3" json:"partitionNum,omitempty"`
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CacheHits   uint64 `protobuf:"varint,2,opt,name=cacheHits,proto3" json:"cacheHits,omitempty"`
+	CacheMisses uint64 `protobuf:"varint,3,opt,name=cacheMisses,proto3" json:"cacheMisses,omitempty"`
+	CacheBytes  int64  `protobuf:"varint,4,opt,name=cacheBytes,proto3" json:"cacheBytes,omitempty"`
+	CacheMsgs   int64  `protobuf:"varint,5,opt,name=cacheMsgs,proto3" json:"cacheMsgs,omitempty"`
+	CorruptMsgs uint64 `protobuf:"varint,6,opt,name=corruptMsgs,proto3" json:"corruptMsgs,omitempty"`
+	ExpiredMsgs uint64 `protobuf:"varint,7,opt,name=expiredMsgs,proto3" json:"expiredMsgs,omitempty"`
 }
 
-func (x *GetTopicInfoReply) Reset() {
-	*x = GetTopicInfoReply{}
+func (x *GetBrokerStatsReply) Reset() {
+	*x = GetBrokerStatsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msg_proto_msgTypes[21]
+		mi := &file_msg_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetTopicInfoReply) String() string {
+func (x *GetBrokerStatsReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTopicInfoReply) ProtoMessage() {}
+func (*GetBrokerStatsReply) ProtoMessage() {}
 
-func (x *GetTopicInfoReply) ProtoReflect() protoreflect.Message {
-	mi := &file_msg_proto_msgTypes[21]
+func (x *GetBrokerStatsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1384,25 +3115,136 @@ func (x *GetTopicInfoReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTopicInfoReply.ProtoReflect.Descriptor instead.
-func (*GetTopicInfoReply) Descriptor() ([]byte, []int) {
-	return file_msg_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use GetBrokerStatsReply.ProtoReflect.Descriptor instead.
+func (*GetBrokerStatsReply) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *GetTopicInfoReply) GetName() string {
+func (x *GetBrokerStatsReply) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *GetTopicInfoReply) GetPartitionNum() int32 {
+func (x *GetBrokerStatsReply) GetCacheHits() uint64 {
 	if x != nil {
-		return x.PartitionNum
+		return x.CacheHits
+	}
+	return 0
+}
+
+func (x *GetBrokerStatsReply) GetCacheMisses() uint64 {
+	if x != nil {
+		return x.CacheMisses
+	}
+	return 0
+}
+
+func (x *GetBrokerStatsReply) GetCacheBytes() int64 {
+	if x != nil {
+		return x.CacheBytes
+	}
+	return 0
+}
+
+func (x *GetBrokerStatsReply) GetCacheMsgs() int64 {
+	if x != nil {
+		return x.CacheMsgs
+	}
+	return 0
+}
+
+func (x *GetBrokerStatsReply) GetCorruptMsgs() uint64 {
+	if x != nil {
+		return x.CorruptMsgs
+	}
+	return 0
+}
+
+func (x *GetBrokerStatsReply) GetExpiredMsgs() uint64 {
+	if x != nil {
+		return x.ExpiredMsgs
 	}
 	return 0
 }
 
+type AliveCheckArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AliveCheckArgs) Reset() {
+	*x = AliveCheckArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AliveCheckArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AliveCheckArgs) ProtoMessage() {}
+
+func (x *AliveCheckArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AliveCheckArgs.ProtoReflect.Descriptor instead.
+func (*AliveCheckArgs) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{45}
+}
+
+type AliveCheckReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AliveCheckReply) Reset() {
+	*x = AliveCheckReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msg_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AliveCheckReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AliveCheckReply) ProtoMessage() {}
+
+func (x *AliveCheckReply) ProtoReflect() protoreflect.Message {
+	mi := &file_msg_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AliveCheckReply.ProtoReflect.Descriptor instead.
+func (*AliveCheckReply) Descriptor() ([]byte, []int) {
+	return file_msg_proto_rawDescGZIP(), []int{46}
+}
+
 var File_msg_proto protoreflect.FileDescriptor
 
 var file_msg_proto_rawDesc = []byte{
@@ -1415,94 +3257,84 @@ var file_msg_proto_rawDesc = []byte{
 	0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f,
 	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22, 0x1f, 0x0a, 0x0b,
 	0x4c, 0x6f, 0x6f, 0x6b, 0x55, 0x70, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x75,
-	0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x12, 0x0a,
+	0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x5a, 0x0a,
 	0x10, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x41, 0x72, 0x67,
-	0x73, 0x22, 0x25, 0x0a, 0x11, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f,
-	0x63, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0xa9, 0x01, 0x0a, 0x0b, 0x43, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03,
-	0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x12,
-	0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65,
-	0x64, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61, 0x72,
-	0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69,
-	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x69, 0x6d,
-	0x65, 0x6f, 0x75, 0x74, 0x22, 0x22, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xb5, 0x02, 0x0a, 0x0d, 0x53, 0x75, 0x62,
-	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
-	0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72,
-	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x75, 0x62,
-	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x41, 0x72, 0x67, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x4d, 0x6f,
-	0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75,
-	0x62, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73,
-	0x75, 0x62, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22, 0x42, 0x0a, 0x07,
-	0x53, 0x75, 0x62, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x78, 0x63, 0x6c, 0x75,
-	0x73, 0x69, 0x76, 0x65, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
-	0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x10, 0x02,
-	0x12, 0x0e, 0x0a, 0x0a, 0x4b, 0x65, 0x79, 0x5f, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x10, 0x03,
-	0x22, 0x26, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xbe, 0x01, 0x0a, 0x08, 0x50, 0x75, 0x6c,
-	0x6c, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70,
-	0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12,
-	0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a,
-	0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75, 0x66, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x07, 0x62, 0x75, 0x66, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74,
-	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x69,
-	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22, 0x21, 0x0a, 0x09, 0x50, 0x75, 0x6c,
-	0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x91, 0x01, 0x0a,
-	0x0f, 0x55, 0x6e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x41, 0x72, 0x67, 0x73,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61, 0x72, 0x74,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22, 0x25, 0x0a, 0x11, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
+	0x22, 0xbf, 0x02, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x41, 0x72, 0x67, 0x73,
 	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61,
-	0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70,
-	0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
-	0x72, 0x65, 0x64, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f,
-	0x22, 0x12, 0x0a, 0x10, 0x55, 0x6e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0xa9, 0x01, 0x0a, 0x0b, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
-	0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69,
-	0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c,
-	0x0a, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03,
-	0x6d, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x12,
-	0x0a, 0x04, 0x6d, 0x73, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x6d, 0x73,
-	0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04,
-	0x72, 0x65, 0x64, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f,
-	0x22, 0x0e, 0x0a, 0x0c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0xa5, 0x01, 0x0a, 0x07, 0x4d, 0x73, 0x67, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x73, 0x69, 0x64, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x6d, 0x73, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61,
-	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x79,
-	0x6c, 0x6f, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22, 0x0a, 0x0a, 0x08, 0x4d, 0x73, 0x67, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x96, 0x01, 0x0a, 0x0a, 0x4d, 0x73, 0x67, 0x41, 0x63, 0x6b, 0x41,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x70, 0x69, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63,
+	0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x75, 0x62, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70,
+	0x75, 0x62, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x70, 0x61,
+	0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f,
+	0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d,
+	0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0d, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x50, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x22, 0x6c, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x6f, 0x6d,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x6c, 0x61, 0x73, 0x74,
+	0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64,
+	0x22, 0xd4, 0x03, 0x0a, 0x0d, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x41, 0x72,
+	0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c, 0x0a, 0x09,
+	0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30,
+	0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x41, 0x72,
+	0x67, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x75, 0x62, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x72, 0x65, 0x64, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x37, 0x0a, 0x0a, 0x64, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74,
+	0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x52, 0x0a, 0x64, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x12, 0x34, 0x0a,
+	0x0b, 0x6e, 0x61, 0x63, 0x6b, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x61, 0x63, 0x6b, 0x42,
+	0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x52, 0x0b, 0x6e, 0x61, 0x63, 0x6b, 0x42, 0x61, 0x63, 0x6b,
+	0x6f, 0x66, 0x66, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x22, 0x42, 0x0a, 0x07, 0x53, 0x75, 0x62, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0d,
+	0x0a, 0x09, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x10, 0x00, 0x12, 0x0a, 0x0a,
+	0x06, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x61, 0x69,
+	0x6c, 0x6f, 0x76, 0x65, 0x72, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x4b, 0x65, 0x79, 0x5f, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x10, 0x03, 0x22, 0x4e, 0x0a, 0x10, 0x44, 0x65, 0x61, 0x64, 0x4c,
+	0x65, 0x74, 0x74, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x6d,
+	0x61, 0x78, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x22, 0x45, 0x0a, 0x0b, 0x4e, 0x61, 0x63, 0x6b, 0x42,
+	0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x69, 0x6e, 0x44, 0x65, 0x6c,
+	0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x44, 0x65, 0x6c,
+	0x61, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x22, 0x26,
+	0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xbe, 0x01, 0x0a, 0x08, 0x50, 0x75, 0x6c, 0x6c, 0x41,
 	0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c, 0x0a,
@@ -1510,63 +3342,352 @@ var file_msg_proto_rawDesc = []byte{
 	0x52, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x73,
 	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x1c, 0x0a, 0x09, 0x61, 0x63, 0x6b, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x09, 0x61, 0x63, 0x6b, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x0d, 0x0a,
-	0x0b, 0x4d, 0x73, 0x67, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x0f, 0x0a, 0x0d,
-	0x41, 0x63, 0x6b, 0x32, 0x70, 0x75, 0x62, 0x65, 0x72, 0x41, 0x72, 0x67, 0x73, 0x22, 0x10, 0x0a,
-	0x0e, 0x41, 0x63, 0x6b, 0x32, 0x70, 0x75, 0x62, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x50, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x41,
-	0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x12, 0x0a,
-	0x04, 0x72, 0x65, 0x64, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64,
-	0x6f, 0x22, 0x4b, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x18, 0x0a, 0x07, 0x62, 0x75, 0x66, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x62, 0x75, 0x66, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22, 0x21, 0x0a, 0x09, 0x50, 0x75, 0x6c, 0x6c, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x91, 0x01, 0x0a, 0x0f, 0x55,
+	0x6e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61, 0x72,
+	0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65,
+	0x64, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22, 0x12,
+	0x0a, 0x10, 0x55, 0x6e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0xa2, 0x04, 0x0a, 0x0b, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x41, 0x72,
+	0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c, 0x0a, 0x09,
+	0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6d, 0x73, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x6d, 0x73, 0x69, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65,
+	0x64, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x12, 0x20,
+	0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x72, 0x63, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x03, 0x63, 0x72, 0x63, 0x12, 0x42, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69,
+	0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x41, 0x72, 0x67, 0x73, 0x2e, 0x50, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70, 0x72,
+	0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x41, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x41, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x70,
+	0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x34, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x2b, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x88, 0x02,
+	0x0a, 0x0a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x41, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70,
+	0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x2e,
+	0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c,
+	0x69, 0x76, 0x65, 0x72, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x41, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x40, 0x0a, 0x0c, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x73, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x73, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x6d, 0x73, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x22, 0x8e, 0x04, 0x0a, 0x07, 0x4d,
+	0x73, 0x67, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63,
+	0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6d, 0x73, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x6d, 0x73, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65,
+	0x64, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x75, 0x62, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x73, 0x75, 0x62, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63,
+	0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x63, 0x72, 0x63, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x63, 0x72, 0x63, 0x12, 0x3e,
+	0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x73, 0x67, 0x41, 0x72,
+	0x67, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x22,
+	0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x72, 0x65, 0x64,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x3d, 0x0a, 0x0f,
+	0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x0a, 0x0a, 0x08, 0x4d,
+	0x73, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xc0, 0x01, 0x0a, 0x0a, 0x4d, 0x73, 0x67, 0x41,
+	0x63, 0x6b, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63,
+	0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22,
+	0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x63, 0x6b, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x61, 0x63, 0x6b, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x69, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x04, 0x52,
+	0x05, 0x6d, 0x73, 0x69, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22, 0x0d, 0x0a, 0x0b, 0x4d, 0x73,
+	0x67, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xa0, 0x01, 0x0a, 0x08, 0x4e, 0x61,
+	0x63, 0x6b, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63,
+	0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22,
+	0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x69, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x05, 0x6d, 0x73, 0x69, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22, 0x0b, 0x0a, 0x09,
+	0x4e, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x0f, 0x0a, 0x0d, 0x41, 0x63, 0x6b,
+	0x32, 0x70, 0x75, 0x62, 0x65, 0x72, 0x41, 0x72, 0x67, 0x73, 0x22, 0x10, 0x0a, 0x0e, 0x41, 0x63,
+	0x6b, 0x32, 0x70, 0x75, 0x62, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x50, 0x0a, 0x10,
+	0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x41, 0x72, 0x67, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65,
+	0x64, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22, 0x4b,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x70,
+	0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x22, 0xc1, 0x01, 0x0a, 0x0f,
+	0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x4d, 0x73, 0x67, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x4d, 0x73, 0x67, 0x73, 0x12, 0x24, 0x0a,
+	0x0d, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x6e, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x6e, 0x61, 0x63,
+	0x6b, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x6b,
+	0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x41, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x22,
+	0x2f, 0x0a, 0x0d, 0x4f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x48, 0x6f, 0x74, 0x4d, 0x73, 0x67, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x48, 0x6f, 0x74, 0x4d, 0x73, 0x67, 0x73,
+	0x22, 0x36, 0x0a, 0x0c, 0x4f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x91, 0x02, 0x0a, 0x0e, 0x50, 0x61, 0x72,
+	0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70,
+	0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6e, 0x75,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x6d, 0x6e, 0x75, 0x6d, 0x12, 0x1c, 0x0a,
+	0x09, 0x61, 0x63, 0x6b, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x61, 0x63, 0x6b, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70,
+	0x75, 0x73, 0x68, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x70, 0x75, 0x73, 0x68, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0c, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x4f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x63, 0x74, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x31, 0x0a, 0x09, 0x6f, 0x66, 0x66,
+	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x09, 0x6f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x22, 0x51, 0x0a, 0x11,
+	0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x41, 0x72, 0x67,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x65, 0x64, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22,
+	0xb3, 0x02, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x61,
 	0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x32, 0x9b,
-	0x04, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x34, 0x0a, 0x07, 0x43, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x12, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x3b, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x53, 0x75, 0x62, 0x12, 0x14, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x41,
-	0x72, 0x67, 0x73, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x75, 0x62, 0x73,
-	0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c,
-	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x55, 0x6e, 0x53, 0x75, 0x62, 0x12, 0x16, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x6e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
-	0x41, 0x72, 0x67, 0x73, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x6e, 0x53,
-	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x32, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x50, 0x75, 0x6c, 0x6c, 0x12, 0x0f,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x6c, 0x6c, 0x41, 0x72, 0x67, 0x73, 0x1a,
-	0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x50, 0x75,
-	0x62, 0x12, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73,
-	0x68, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75,
-	0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x06,
-	0x4d, 0x73, 0x67, 0x41, 0x63, 0x6b, 0x12, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d,
-	0x73, 0x67, 0x41, 0x63, 0x6b, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x4d, 0x73, 0x67, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x31, 0x0a, 0x06, 0x4c, 0x6f, 0x6f, 0x6b, 0x55, 0x70, 0x12, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x55, 0x70, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x12, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x55, 0x70, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x6c, 0x6c,
-	0x6f, 0x63, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x18, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x63,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x54, 0x6f,
-	0x70, 0x69, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x41, 0x72, 0x67, 0x73,
-	0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x69,
-	0x63, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x32, 0x75, 0x0a, 0x06,
-	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x2f, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
-	0x73, 0x4d, 0x73, 0x67, 0x12, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x73, 0x67,
-	0x41, 0x72, 0x67, 0x73, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x73, 0x67,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x09, 0x41, 0x63, 0x6b, 0x32, 0x70,
-	0x75, 0x62, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x63, 0x6b,
-	0x32, 0x70, 0x75, 0x62, 0x65, 0x72, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x41, 0x63, 0x6b, 0x32, 0x70, 0x75, 0x62, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x42, 0x0d, 0x5a, 0x0b, 0x4d, 0x78, 0x63, 0x4d, 0x51, 0x2f, 0x70, 0x72, 0x6f,
+	0x52, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x12, 0x34,
+	0x0a, 0x09, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74,
+	0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x09, 0x72, 0x65, 0x74, 0x65, 0x6e,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x0a, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x6f,
+	0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x52, 0x07, 0x6f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x28, 0x0a, 0x05, 0x71,
+	0x75, 0x6f, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x05,
+	0x71, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x54, 0x54, 0x4c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x54, 0x54, 0x4c, 0x22, 0x86, 0x01, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x52, 0x65, 0x74,
+	0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x6f, 0x70, 0x69, 0x63, 0x12, 0x34, 0x0a, 0x09, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x09, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65,
+	0x64, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22, 0x13,
+	0x0a, 0x11, 0x53, 0x65, 0x74, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x7e, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x6c, 0x6f, 0x61,
+	0x64, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12,
+	0x2e, 0x0a, 0x07, 0x6f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x07, 0x6f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72,
+	0x65, 0x64, 0x6f, 0x22, 0x11, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x6c, 0x6f, 0x61,
+	0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x77, 0x0a, 0x0b, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x63, 0x6b,
+	0x6c, 0x6f, 0x67, 0x4d, 0x73, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6d,
+	0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x4d, 0x73, 0x67, 0x73, 0x12, 0x28, 0x0a,
+	0x0f, 0x6d, 0x61, 0x78, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x76, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x41, 0x72, 0x67, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x28, 0x0a, 0x05, 0x71, 0x75, 0x6f,
+	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x05, 0x71, 0x75,
+	0x6f, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22, 0x0f, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x51, 0x75,
+	0x6f, 0x74, 0x61, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x71, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x54, 0x4c, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x54, 0x54, 0x4c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x54, 0x54, 0x4c, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22, 0x14, 0x0a, 0x12, 0x53,
+	0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x54, 0x4c, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x3c, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x65, 0x64, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x65, 0x64, 0x6f, 0x22,
+	0xeb, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x63, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x61, 0x63,
+	0x68, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
+	0x63, 0x61, 0x63, 0x68, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63,
+	0x61, 0x63, 0x68, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x63, 0x61, 0x63, 0x68, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x61, 0x63, 0x68, 0x65, 0x4d, 0x73, 0x67, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x63, 0x61, 0x63, 0x68, 0x65, 0x4d, 0x73, 0x67, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x72,
+	0x72, 0x75, 0x70, 0x74, 0x4d, 0x73, 0x67, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
+	0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x4d, 0x73, 0x67, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x22, 0x10, 0x0a,
+	0x0e, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x41, 0x72, 0x67, 0x73, 0x22,
+	0x11, 0x0a, 0x0f, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x32, 0xe0, 0x07, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x34, 0x0a,
+	0x07, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x13, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x53, 0x75,
+	0x62, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x41, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x55, 0x6e, 0x53, 0x75, 0x62,
+	0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x6e, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x55, 0x6e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x50, 0x75,
+	0x6c, 0x6c, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x6c, 0x6c, 0x41,
+	0x72, 0x67, 0x73, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x6c, 0x6c,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x50, 0x75, 0x62, 0x12, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x31, 0x0a, 0x06, 0x4d, 0x73, 0x67, 0x41, 0x63, 0x6b, 0x12, 0x11, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x4d, 0x73, 0x67, 0x41, 0x63, 0x6b, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x12, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x73, 0x67, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x04, 0x4e, 0x61, 0x63, 0x6b, 0x12, 0x0f, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x61, 0x63, 0x6b, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x10, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x31, 0x0a, 0x06, 0x4c, 0x6f, 0x6f, 0x6b, 0x55, 0x70, 0x12, 0x11, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x55, 0x70, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x12, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x55, 0x70, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x6c,
+	0x6c, 0x6f, 0x63, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x18, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f,
+	0x63, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x54,
+	0x6f, 0x70, 0x69, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x41, 0x72, 0x67,
+	0x73, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70,
+	0x69, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x46, 0x0a,
+	0x0d, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x52, 0x65, 0x74, 0x65,
+	0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65,
+	0x74, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x18,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0a, 0x53, 0x65,
+	0x74, 0x4f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x53, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x72, 0x67, 0x73, 0x1a,
+	0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x08, 0x53, 0x65, 0x74,
+	0x51, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65,
+	0x74, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x46, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x54, 0x54, 0x4c, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x54, 0x4c, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x19, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x54, 0x54, 0x4c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0e, 0x47, 0x65,
+	0x74, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x19, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x47, 0x65, 0x74, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x32, 0xb4, 0x01, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x12, 0x2f, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4d, 0x73, 0x67, 0x12, 0x0e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x73, 0x67, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x0f,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x3a, 0x0a, 0x09, 0x41, 0x63, 0x6b, 0x32, 0x70, 0x75, 0x62, 0x65, 0x72, 0x12, 0x14,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x63, 0x6b, 0x32, 0x70, 0x75, 0x62, 0x65, 0x72,
+	0x41, 0x72, 0x67, 0x73, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x63, 0x6b,
+	0x32, 0x70, 0x75, 0x62, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3d, 0x0a,
+	0x0a, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x15, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x41, 0x72,
+	0x67, 0x73, 0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6c, 0x69, 0x76, 0x65,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x14, 0x5a, 0x12,
+	0x4d, 0x78, 0x63, 0x4d, 0x51, 0x2d, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
@@ -1583,61 +3704,119 @@ func file_msg_proto_rawDescGZIP() []byte {
 }
 
 var file_msg_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_msg_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_msg_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
 var file_msg_proto_goTypes = []interface{}{
-	(SubscribeArgs_SubMode)(0), // 0: proto.SubscribeArgs.SubMode
-	(*LookUpArgs)(nil),         // 1: proto.LookUpArgs
-	(*LookUpReply)(nil),        // 2: proto.LookUpReply
-	(*RequestAllocArgs)(nil),   // 3: proto.RequestAllocArgs
-	(*RequestAllocReply)(nil),  // 4: proto.RequestAllocReply
-	(*ConnectArgs)(nil),        // 5: proto.ConnectArgs
-	(*ConnectReply)(nil),       // 6: proto.ConnectReply
-	(*SubscribeArgs)(nil),      // 7: proto.SubscribeArgs
-	(*SubscribeReply)(nil),     // 8: proto.SubscribeReply
-	(*PullArgs)(nil),           // 9: proto.PullArgs
-	(*PullReply)(nil),          // 10: proto.PullReply
-	(*UnSubscribeArgs)(nil),    // 11: proto.UnSubscribeArgs
-	(*UnSubscribeReply)(nil),   // 12: proto.UnSubscribeReply
-	(*PublishArgs)(nil),        // 13: proto.PublishArgs
-	(*PublishReply)(nil),       // 14: proto.PublishReply
-	(*MsgArgs)(nil),            // 15: proto.MsgArgs
-	(*MsgReply)(nil),           // 16: proto.MsgReply
-	(*MsgAckArgs)(nil),         // 17: proto.MsgAckArgs
-	(*MsgAckReply)(nil),        // 18: proto.MsgAckReply
-	(*Ack2PuberArgs)(nil),      // 19: proto.Ack2puberArgs
-	(*Ack2PuberReply)(nil),     // 20: proto.Ack2puberReply
-	(*GetTopicInfoArgs)(nil),   // 21: proto.GetTopicInfoArgs
-	(*GetTopicInfoReply)(nil),  // 22: proto.GetTopicInfoReply
+	(SubscribeArgs_SubMode)(0),  // 0: proto.SubscribeArgs.SubMode
+	(*LookUpArgs)(nil),          // 1: proto.LookUpArgs
+	(*LookUpReply)(nil),         // 2: proto.LookUpReply
+	(*RequestAllocArgs)(nil),    // 3: proto.RequestAllocArgs
+	(*RequestAllocReply)(nil),   // 4: proto.RequestAllocReply
+	(*ConnectArgs)(nil),         // 5: proto.ConnectArgs
+	(*ConnectReply)(nil),        // 6: proto.ConnectReply
+	(*SubscribeArgs)(nil),       // 7: proto.SubscribeArgs
+	(*DeadLetterPolicy)(nil),    // 8: proto.DeadLetterPolicy
+	(*NackBackoff)(nil),         // 9: proto.NackBackoff
+	(*SubscribeReply)(nil),      // 10: proto.SubscribeReply
+	(*PullArgs)(nil),            // 11: proto.PullArgs
+	(*PullReply)(nil),           // 12: proto.PullReply
+	(*UnSubscribeArgs)(nil),     // 13: proto.UnSubscribeArgs
+	(*UnSubscribeReply)(nil),    // 14: proto.UnSubscribeReply
+	(*PublishArgs)(nil),         // 15: proto.PublishArgs
+	(*Batch)(nil),               // 16: proto.Batch
+	(*BatchEntry)(nil),          // 17: proto.BatchEntry
+	(*PublishReply)(nil),        // 18: proto.PublishReply
+	(*MsgArgs)(nil),             // 19: proto.MsgArgs
+	(*MsgReply)(nil),            // 20: proto.MsgReply
+	(*MsgAckArgs)(nil),          // 21: proto.MsgAckArgs
+	(*MsgAckReply)(nil),         // 22: proto.MsgAckReply
+	(*NackArgs)(nil),            // 23: proto.NackArgs
+	(*NackReply)(nil),           // 24: proto.NackReply
+	(*Ack2PuberArgs)(nil),       // 25: proto.Ack2puberArgs
+	(*Ack2PuberReply)(nil),      // 26: proto.Ack2puberReply
+	(*GetTopicInfoArgs)(nil),    // 27: proto.GetTopicInfoArgs
+	(*GetTopicInfoReply)(nil),   // 28: proto.GetTopicInfoReply
+	(*RetentionPolicy)(nil),     // 29: proto.RetentionPolicy
+	(*OffloadPolicy)(nil),       // 30: proto.OffloadPolicy
+	(*OffloadRange)(nil),        // 31: proto.OffloadRange
+	(*PartitionStats)(nil),      // 32: proto.PartitionStats
+	(*GetTopicStatsArgs)(nil),   // 33: proto.GetTopicStatsArgs
+	(*GetTopicStatsReply)(nil),  // 34: proto.GetTopicStatsReply
+	(*SetRetentionArgs)(nil),    // 35: proto.SetRetentionArgs
+	(*SetRetentionReply)(nil),   // 36: proto.SetRetentionReply
+	(*SetOffloadArgs)(nil),      // 37: proto.SetOffloadArgs
+	(*SetOffloadReply)(nil),     // 38: proto.SetOffloadReply
+	(*QuotaPolicy)(nil),         // 39: proto.QuotaPolicy
+	(*SetQuotaArgs)(nil),        // 40: proto.SetQuotaArgs
+	(*SetQuotaReply)(nil),       // 41: proto.SetQuotaReply
+	(*SetMessageTTLArgs)(nil),   // 42: proto.SetMessageTTLArgs
+	(*SetMessageTTLReply)(nil),  // 43: proto.SetMessageTTLReply
+	(*GetBrokerStatsArgs)(nil),  // 44: proto.GetBrokerStatsArgs
+	(*GetBrokerStatsReply)(nil), // 45: proto.GetBrokerStatsReply
+	(*AliveCheckArgs)(nil),      // 46: proto.AliveCheckArgs
+	(*AliveCheckReply)(nil),     // 47: proto.AliveCheckReply
+	nil,                         // 48: proto.PublishArgs.PropertiesEntry
+	nil,                         // 49: proto.BatchEntry.PropertiesEntry
+	nil,                         // 50: proto.MsgArgs.PropertiesEntry
 }
 var file_msg_proto_depIdxs = []int32{
 	0,  // 0: proto.SubscribeArgs.mode:type_name -> proto.SubscribeArgs.SubMode
-	5,  // 1: proto.Server.Connect:input_type -> proto.ConnectArgs
-	7,  // 2: proto.Server.ProcessSub:input_type -> proto.SubscribeArgs
-	11, // 3: proto.Server.ProcessUnSub:input_type -> proto.UnSubscribeArgs
-	9,  // 4: proto.Server.ProcessPull:input_type -> proto.PullArgs
-	13, // 5: proto.Server.ProcessPub:input_type -> proto.PublishArgs
-	17, // 6: proto.Server.MsgAck:input_type -> proto.MsgAckArgs
-	1,  // 7: proto.Server.LookUp:input_type -> proto.LookUpArgs
-	3,  // 8: proto.Server.RequestAlloc:input_type -> proto.RequestAllocArgs
-	21, // 9: proto.Server.GetTopicInfo:input_type -> proto.GetTopicInfoArgs
-	15, // 10: proto.Client.ProcessMsg:input_type -> proto.MsgArgs
-	19, // 11: proto.Client.Ack2puber:input_type -> proto.Ack2puberArgs
-	6,  // 12: proto.Server.Connect:output_type -> proto.ConnectReply
-	8,  // 13: proto.Server.ProcessSub:output_type -> proto.SubscribeReply
-	12, // 14: proto.Server.ProcessUnSub:output_type -> proto.UnSubscribeReply
-	10, // 15: proto.Server.ProcessPull:output_type -> proto.PullReply
-	14, // 16: proto.Server.ProcessPub:output_type -> proto.PublishReply
-	18, // 17: proto.Server.MsgAck:output_type -> proto.MsgAckReply
-	2,  // 18: proto.Server.LookUp:output_type -> proto.LookUpReply
-	4,  // 19: proto.Server.RequestAlloc:output_type -> proto.RequestAllocReply
-	22, // 20: proto.Server.GetTopicInfo:output_type -> proto.GetTopicInfoReply
-	16, // 21: proto.Client.ProcessMsg:output_type -> proto.MsgReply
-	20, // 22: proto.Client.Ack2puber:output_type -> proto.Ack2puberReply
-	12, // [12:23] is the sub-list for method output_type
-	1,  // [1:12] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	8,  // 1: proto.SubscribeArgs.deadLetter:type_name -> proto.DeadLetterPolicy
+	9,  // 2: proto.SubscribeArgs.nackBackoff:type_name -> proto.NackBackoff
+	48, // 3: proto.PublishArgs.properties:type_name -> proto.PublishArgs.PropertiesEntry
+	17, // 4: proto.Batch.entries:type_name -> proto.BatchEntry
+	49, // 5: proto.BatchEntry.properties:type_name -> proto.BatchEntry.PropertiesEntry
+	50, // 6: proto.MsgArgs.properties:type_name -> proto.MsgArgs.PropertiesEntry
+	31, // 7: proto.PartitionStats.offloaded:type_name -> proto.OffloadRange
+	29, // 8: proto.GetTopicStatsReply.retention:type_name -> proto.RetentionPolicy
+	32, // 9: proto.GetTopicStatsReply.partitions:type_name -> proto.PartitionStats
+	30, // 10: proto.GetTopicStatsReply.offload:type_name -> proto.OffloadPolicy
+	39, // 11: proto.GetTopicStatsReply.quota:type_name -> proto.QuotaPolicy
+	29, // 12: proto.SetRetentionArgs.retention:type_name -> proto.RetentionPolicy
+	30, // 13: proto.SetOffloadArgs.offload:type_name -> proto.OffloadPolicy
+	39, // 14: proto.SetQuotaArgs.quota:type_name -> proto.QuotaPolicy
+	5,  // 15: proto.Server.Connect:input_type -> proto.ConnectArgs
+	7,  // 16: proto.Server.ProcessSub:input_type -> proto.SubscribeArgs
+	13, // 17: proto.Server.ProcessUnSub:input_type -> proto.UnSubscribeArgs
+	11, // 18: proto.Server.ProcessPull:input_type -> proto.PullArgs
+	15, // 19: proto.Server.ProcessPub:input_type -> proto.PublishArgs
+	21, // 20: proto.Server.MsgAck:input_type -> proto.MsgAckArgs
+	23, // 21: proto.Server.Nack:input_type -> proto.NackArgs
+	1,  // 22: proto.Server.LookUp:input_type -> proto.LookUpArgs
+	3,  // 23: proto.Server.RequestAlloc:input_type -> proto.RequestAllocArgs
+	27, // 24: proto.Server.GetTopicInfo:input_type -> proto.GetTopicInfoArgs
+	33, // 25: proto.Server.GetTopicStats:input_type -> proto.GetTopicStatsArgs
+	35, // 26: proto.Server.SetRetention:input_type -> proto.SetRetentionArgs
+	37, // 27: proto.Server.SetOffload:input_type -> proto.SetOffloadArgs
+	40, // 28: proto.Server.SetQuota:input_type -> proto.SetQuotaArgs
+	42, // 29: proto.Server.SetMessageTTL:input_type -> proto.SetMessageTTLArgs
+	44, // 30: proto.Server.GetBrokerStats:input_type -> proto.GetBrokerStatsArgs
+	19, // 31: proto.Client.ProcessMsg:input_type -> proto.MsgArgs
+	25, // 32: proto.Client.Ack2puber:input_type -> proto.Ack2puberArgs
+	46, // 33: proto.Client.AliveCheck:input_type -> proto.AliveCheckArgs
+	6,  // 34: proto.Server.Connect:output_type -> proto.ConnectReply
+	10, // 35: proto.Server.ProcessSub:output_type -> proto.SubscribeReply
+	14, // 36: proto.Server.ProcessUnSub:output_type -> proto.UnSubscribeReply
+	12, // 37: proto.Server.ProcessPull:output_type -> proto.PullReply
+	18, // 38: proto.Server.ProcessPub:output_type -> proto.PublishReply
+	22, // 39: proto.Server.MsgAck:output_type -> proto.MsgAckReply
+	24, // 40: proto.Server.Nack:output_type -> proto.NackReply
+	2,  // 41: proto.Server.LookUp:output_type -> proto.LookUpReply
+	4,  // 42: proto.Server.RequestAlloc:output_type -> proto.RequestAllocReply
+	28, // 43: proto.Server.GetTopicInfo:output_type -> proto.GetTopicInfoReply
+	34, // 44: proto.Server.GetTopicStats:output_type -> proto.GetTopicStatsReply
+	36, // 45: proto.Server.SetRetention:output_type -> proto.SetRetentionReply
+	38, // 46: proto.Server.SetOffload:output_type -> proto.SetOffloadReply
+	41, // 47: proto.Server.SetQuota:output_type -> proto.SetQuotaReply
+	43, // 48: proto.Server.SetMessageTTL:output_type -> proto.SetMessageTTLReply
+	45, // 49: proto.Server.GetBrokerStats:output_type -> proto.GetBrokerStatsReply
+	20, // 50: proto.Client.ProcessMsg:output_type -> proto.MsgReply
+	26, // 51: proto.Client.Ack2puber:output_type -> proto.Ack2puberReply
+	47, // 52: proto.Client.AliveCheck:output_type -> proto.AliveCheckReply
+	34, // [34:53] is the sub-list for method output_type
+	15, // [15:34] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_msg_proto_init() }
@@ -1658,8 +3837,80 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LookUpReply); i {
+		file_msg_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookUpReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestAllocArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestAllocReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConnectArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConnectReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeadLetterPolicy); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1670,8 +3921,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestAllocArgs); i {
+		file_msg_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NackBackoff); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1682,8 +3933,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestAllocReply); i {
+		file_msg_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1694,8 +3945,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConnectArgs); i {
+		file_msg_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PullArgs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1706,8 +3957,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConnectReply); i {
+		file_msg_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PullReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1718,8 +3969,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubscribeArgs); i {
+		file_msg_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnSubscribeArgs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1730,8 +3981,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubscribeReply); i {
+		file_msg_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnSubscribeReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1742,8 +3993,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PullArgs); i {
+		file_msg_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublishArgs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1754,8 +4005,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PullReply); i {
+		file_msg_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Batch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1766,8 +4017,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnSubscribeArgs); i {
+		file_msg_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1778,8 +4029,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnSubscribeReply); i {
+		file_msg_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublishReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1790,8 +4041,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PublishArgs); i {
+		file_msg_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgArgs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1802,8 +4053,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PublishReply); i {
+		file_msg_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1814,8 +4065,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgArgs); i {
+		file_msg_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgAckArgs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1826,8 +4077,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgReply); i {
+		file_msg_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgAckReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1838,8 +4089,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgAckArgs); i {
+		file_msg_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NackArgs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1850,8 +4101,8 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgAckReply); i {
+		file_msg_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NackReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1862,7 +4113,7 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_msg_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Ack2PuberArgs); i {
 			case 0:
 				return &v.state
@@ -1874,7 +4125,7 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_msg_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Ack2PuberReply); i {
 			case 0:
 				return &v.state
@@ -1886,7 +4137,7 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_msg_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetTopicInfoArgs); i {
 			case 0:
 				return &v.state
@@ -1898,7 +4149,7 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
-		file_msg_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_msg_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetTopicInfoReply); i {
 			case 0:
 				return &v.state
@@ -1910,6 +4161,234 @@ func file_msg_proto_init() {
 				return nil
 			}
 		}
+		file_msg_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetentionPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OffloadPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OffloadRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PartitionStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTopicStatsArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTopicStatsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetRetentionArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetRetentionReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetOffloadArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetOffloadReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuotaPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetQuotaArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetQuotaReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetMessageTTLArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetMessageTTLReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBrokerStatsArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBrokerStatsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AliveCheckArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msg_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AliveCheckReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1917,7 +4396,7 @@ func file_msg_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_msg_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   22,
+			NumMessages:   50,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
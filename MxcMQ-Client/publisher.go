@@ -60,12 +60,16 @@ func (p *Publisher) Connect() error {
 		PartitionNum: int32(p.Opt.partitionNum),
 		PubMode:      int32(p.Opt.mode),
 		Timeout:      int32(p.Opt.ConnectTimeout),
+		Compression:  int32(p.Opt.compression),
 	}
 	name, err := p.client.Connect(p.Opt.srvUrl, args)
 	if err != nil {
 		return err
 	}
 	p.fullName = name
+	if p.Opt.compression == Compression_None {
+		p.Opt.compression = p.client.Compression
+	}
 
 	go p.asyncPush()
 
@@ -73,21 +77,59 @@ func (p *Publisher) Connect() error {
 }
 
 func (p *Publisher) Publish(m *Msg) error {
+	payload, err := Compress(p.Opt.compression, m.Data)
+	if err != nil {
+		return err
+	}
 	args := &pb.PublishArgs{
-		Name:      p.fullName,
-		Topic:     m.Topic,
-		Partition: int32(m.Partition),
-		Mid:       nrand(),
-		Payload:   string(m.Data),
-		Redo:      0,
-	}
-	_, err := p.client.Push2serverWithRedo(args, p.Opt.OperationTimeout)
+		Name:         p.fullName,
+		Topic:        m.Topic,
+		Partition:    int32(m.Partition),
+		Mid:          nrand(),
+		Key:          m.Key,
+		Payload:      payload,
+		Redo:         0,
+		Compression:  int32(p.Opt.compression),
+		Crc:          Checksum(payload),
+		Properties:   m.Properties,
+		EventTime:    m.EventTime,
+		DeliverAt:    m.deliverAt(),
+		Ttl:          m.TTL.Milliseconds(),
+		ProducerName: p.Opt.name,
+	}
+	_, err = p.client.publish(args, p.Opt.OperationTimeout)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// PublishBatch publishes ms in a single request, compressed as a whole. The
+// broker stores them one by one in the order of ms.
+func (p *Publisher) PublishBatch(ms []*Msg) error {
+	if len(ms) == 0 {
+		return nil
+	}
+	payload, err := newBatch(p.Opt.compression, ms)
+	if err != nil {
+		return err
+	}
+	args := &pb.PublishArgs{
+		Name:         p.fullName,
+		Topic:        ms[0].Topic,
+		Partition:    int32(ms[0].Partition),
+		Mid:          nrand(),
+		Payload:      payload,
+		Redo:         0,
+		Compression:  int32(p.Opt.compression),
+		Crc:          Checksum(payload),
+		ProducerName: p.Opt.name,
+		BatchSize:    int32(len(ms)),
+	}
+	_, err = p.client.publish(args, p.Opt.OperationTimeout)
+	return err
+}
+
 // callback ?
 func (p *Publisher) AsyncPublish(m *Msg) error {
 	if p.asyncSend.AsyncSendQueue.Size() >= p.Opt.AsyncMaxSendBufSize {
@@ -12,6 +12,10 @@ type PublisherOpt struct {
 	OperationTimeout    int
 	OperationMaxRedoNum int
 	AsyncMaxSendBufSize int
+	compression         CompressionType
+	routeMode           RouteMode
+	router              Router
+	stickyBatchSize     int
 }
 
 var default_publisher = PublisherOpt{
@@ -21,6 +25,8 @@ var default_publisher = PublisherOpt{
 	OperationTimeout:    30,
 	OperationMaxRedoNum: 3,
 	AsyncMaxSendBufSize: 1000,
+	routeMode:           RMode_RoundRobinPartition,
+	stickyBatchSize:     100,
 }
 
 type PubOption interface {
@@ -76,3 +82,34 @@ func WithpPartitionNum(num int) PubOption {
 		opt.partitionNum = int32(num)
 	})
 }
+
+// WithpCompression sets the compression type of the payloads, it is also the
+// default of the topic if the topic is new. Compression_None means using the
+// default of the topic.
+func WithpCompression(c CompressionType) PubOption {
+	return newfuncPubOption(func(opt *PublisherOpt) {
+		opt.compression = c
+	})
+}
+
+// WithpRouteMode sets how a PartPublisher picks the partition of messages
+// sent without one.
+func WithpRouteMode(mode RouteMode) PubOption {
+	return newfuncPubOption(func(opt *PublisherOpt) {
+		opt.routeMode = mode
+	})
+}
+
+// WithpRouter routes messages with r, in RMode_CustomPartition.
+func WithpRouter(r Router) PubOption {
+	return newfuncPubOption(func(opt *PublisherOpt) {
+		opt.routeMode = RMode_CustomPartition
+		opt.router = r
+	})
+}
+
+func WithpStickyBatchSize(size int) PubOption {
+	return newfuncPubOption(func(opt *PublisherOpt) {
+		opt.stickyBatchSize = size
+	})
+}
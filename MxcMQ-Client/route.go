@@ -0,0 +1,68 @@
+package MxcMQClient
+
+import (
+	"hash/crc32"
+	"sync"
+)
+
+// Router returns the partition of msg, from 1 to numPartitions like the
+// partitions of the broker.
+type Router func(msg *Msg, numPartitions int) int
+
+// router picks the partition of the messages a PartPublisher sends without
+// one.
+type router struct {
+	mu      sync.Mutex
+	mode    RouteMode
+	custom  Router
+	batch   int
+	next    int // 0 based
+	inBatch int
+}
+
+func newRouter(opt *PublisherOpt) *router {
+	return &router{
+		mode:   opt.routeMode,
+		custom: opt.router,
+		batch:  opt.stickyBatchSize,
+	}
+}
+
+func (r *router) route(m *Msg, numPartitions int) int {
+	switch r.mode {
+	case RMode_CustomPartition:
+		if r.custom != nil {
+			return r.custom(m, numPartitions)
+		}
+	case RMode_KeyHashPartition:
+		// same key, same partition, so the order of a key is kept
+		if m.Key != "" {
+			return int(crc32.ChecksumIEEE([]byte(m.Key))%uint32(numPartitions)) + 1
+		}
+	case RMode_StickyPartition:
+		return r.sticky(numPartitions)
+	}
+	return r.roundRobin(numPartitions)
+}
+
+func (r *router) roundRobin(numPartitions int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	part := r.next % numPartitions
+	r.next = part + 1
+	return part + 1
+}
+
+// sticky sends batch messages in a row to the same partition before moving
+// to the next one.
+func (r *router) sticky(numPartitions int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.inBatch >= r.batch {
+		r.next++
+		r.inBatch = 0
+	}
+	r.inBatch++
+	r.next %= numPartitions
+	return r.next + 1
+}
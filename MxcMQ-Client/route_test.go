@@ -0,0 +1,34 @@
+package MxcMQClient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoute(t *testing.T) {
+	byKeyLen := func(m *Msg, numPartitions int) int { return len(m.Key)%numPartitions + 1 }
+	tests := []struct {
+		name string
+		opt  PublisherOpt
+		keys []string
+		want []int
+	}{
+		{"round robin", PublisherOpt{routeMode: RMode_RoundRobinPartition}, []string{"a", "a", "a", "a"}, []int{1, 2, 3, 1}},
+		{"sticky", PublisherOpt{routeMode: RMode_StickyPartition, stickyBatchSize: 2}, []string{"", "", "", "", "", "", ""}, []int{1, 1, 2, 2, 3, 3, 1}},
+		// without a key the round robin goes on
+		{"key hash", PublisherOpt{routeMode: RMode_KeyHashPartition}, []string{"a", "b", "a", "", "order-1", ""}, []int{1, 3, 1, 1, 2, 2}},
+		{"custom", PublisherOpt{routeMode: RMode_CustomPartition, router: byKeyLen}, []string{"", "a", "ab", "abc"}, []int{1, 2, 3, 1}},
+		{"custom without router", PublisherOpt{routeMode: RMode_CustomPartition}, []string{"a", "a"}, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRouter(&tt.opt)
+			var got []int
+			for _, key := range tt.keys {
+				got = append(got, r.route(&Msg{Key: key}, 3))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
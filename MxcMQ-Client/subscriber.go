@@ -72,7 +72,10 @@ func (s *Subscriber) getTopic(sub *subcription) (*Topic, error) {
 func (s *Subscriber) connect(sub *subcription, i int) error {
 	cliUrl := fmt.Sprintf("%v:%v", s.Opt.host, s.Opt.port)
 	// for i := 1; i <= sub.Opt.topic.partitionNum; i++ {
-	client := &Client{OperationMaxRedoNum: int32(s.Opt.OperationMaxRedoNum)}
+	client := &Client{
+		OperationMaxRedoNum: int32(s.Opt.OperationMaxRedoNum),
+		VerifyChecksum:      s.Opt.verifyChecksum,
+	}
 	args := &pb.ConnectArgs{
 		Name:         s.Opt.name,
 		Url:          cliUrl,
@@ -116,6 +119,7 @@ func (s *Subscriber) Subscribe(name string, topic string, opt ...SubscipOption)
 		partition2fullname: make(map[int]string),
 		revQueue:           queue.New(),
 		cancel:             cancel,
+		operationTimeout:   s.Opt.OperationTimeout,
 	}
 
 	cliUrl := fmt.Sprintf("%v:%v", s.Opt.host, s.Opt.port)
@@ -151,6 +155,9 @@ func (s *Subscriber) Subscribe(name string, topic string, opt ...SubscipOption)
 				Subscription: sub.Opt.name,
 				Mode:         pb.SubscribeArgs_SubMode(sub.Opt.mode),
 				Redo:         0,
+				DeadLetter:   sub.Opt.deadLetter,
+				NackBackoff:  sub.Opt.nackBackoff,
+				AckTimeout:   sub.Opt.ackTimeout.Milliseconds(),
 			}
 			_, err := sub.clients[name].Subscribe(args, s.Opt.OperationTimeout)
 			if err != nil {
@@ -184,6 +191,9 @@ func (s *Subscriber) Subscribe(name string, topic string, opt ...SubscipOption)
 				Subscription: sub.Opt.name,
 				Mode:         pb.SubscribeArgs_SubMode(sub.Opt.mode),
 				Redo:         0,
+				DeadLetter:   sub.Opt.deadLetter,
+				NackBackoff:  sub.Opt.nackBackoff,
+				AckTimeout:   sub.Opt.ackTimeout.Milliseconds(),
 			}
 			_, err := sub.clients[name].SubscribeWithRedo(args, s.Opt.OperationTimeout)
 			if err != nil {
@@ -234,6 +244,77 @@ func (sub *subcription) Receive() (*Msg, error) {
 	return msg.(*Msg), nil
 }
 
+// Ack tells the broker msgs are consumed, they are never delivered again.
+func (sub *subcription) Ack(msgs ...*Msg) error {
+	for partition, msids := range msidsByPartition(msgs) {
+		name, ok := sub.partition2fullname[partition]
+		if !ok {
+			return fmt.Errorf("topic/partition %v is not subscribed", partition)
+		}
+		args := &pb.MsgAckArgs{
+			Name:         name,
+			Topic:        sub.Opt.topic.name,
+			Partition:    int32(partition),
+			Subscription: sub.Opt.name,
+			Msids:        msids,
+			Redo:         0,
+		}
+		if _, err := sub.clients[name].MsgAckWithRedo(args, sub.operationTimeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AckCumulative acks m and every message before it in its partition.
+func (sub *subcription) AckCumulative(m *Msg) error {
+	name, ok := sub.partition2fullname[m.Partition]
+	if !ok {
+		return fmt.Errorf("topic/partition %v is not subscribed", m.Partition)
+	}
+	args := &pb.MsgAckArgs{
+		Name:         name,
+		Topic:        sub.Opt.topic.name,
+		Partition:    int32(m.Partition),
+		Subscription: sub.Opt.name,
+		AckOffset:    m.Msid,
+		Redo:         0,
+	}
+	_, err := sub.clients[name].MsgAckWithRedo(args, sub.operationTimeout)
+	return err
+}
+
+// Nack tells the broker msgs failed, they are redelivered after the nack
+// backoff of the subscription.
+func (sub *subcription) Nack(msgs ...*Msg) error {
+	for partition, ids := range msidsByPartition(msgs) {
+		name, ok := sub.partition2fullname[partition]
+		if !ok {
+			return fmt.Errorf("topic/partition %v is not subscribed", partition)
+		}
+		args := &pb.NackArgs{
+			Name:         name,
+			Topic:        sub.Opt.topic.name,
+			Partition:    int32(partition),
+			Subscription: sub.Opt.name,
+			Msids:        ids,
+			Redo:         0,
+		}
+		if _, err := sub.clients[name].NackWithRedo(args, sub.operationTimeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func msidsByPartition(msgs []*Msg) map[int][]uint64 {
+	msids := make(map[int][]uint64)
+	for _, m := range msgs {
+		msids[m.Partition] = append(msids[m.Partition], m.Msid)
+	}
+	return msids
+}
+
 func (s *Subscriber) Unsubscribe(sub *subcription) error {
 	if _, ok := s.sl[sub.Opt.name]; !ok {
 		return errors.New("subscription does not exist")
@@ -8,6 +8,7 @@ type SubscriberOpt struct {
 	ConnectTimeout      int
 	OperationTimeout    int
 	OperationMaxRedoNum int
+	verifyChecksum      bool
 }
 
 var default_subscriber = SubscriberOpt{
@@ -51,3 +52,11 @@ func WithsOperationMaxRedoNum(num int) SuberOption {
 		opt.OperationMaxRedoNum = num
 	})
 }
+
+// WithsVerifyChecksum makes the subscriber check the crc32c of every
+// message it receives.
+func WithsVerifyChecksum(verify bool) SuberOption {
+	return newfuncSubscriberOption(func(opt *SubscriberOpt) {
+		opt.verifyChecksum = verify
+	})
+}
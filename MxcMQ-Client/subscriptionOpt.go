@@ -1,8 +1,10 @@
 package MxcMQClient
 
 import (
+	pb "MxcMQ-Client/proto"
 	"MxcMQ-Client/queue"
 	"context"
+	"time"
 )
 
 type subcription struct {
@@ -14,6 +16,7 @@ type subcription struct {
 	partition2fullname map[int]string
 	revQueue           *queue.Queue
 	cancel             context.CancelFunc
+	operationTimeout   int
 }
 
 type SubscriptionOpt struct {
@@ -24,6 +27,9 @@ type SubscriptionOpt struct {
 	subOffset        uint64
 	receiveQueueSize int
 	pullTimeout      int
+	deadLetter       *pb.DeadLetterPolicy
+	nackBackoff      *pb.NackBackoff
+	ackTimeout       time.Duration
 }
 
 type ReceiveQueue struct {
@@ -86,3 +92,33 @@ func WithspPullTimeout(timeout int) SubscipOption {
 		opt.pullTimeout = timeout
 	})
 }
+
+// WithspDeadLetter publishes the messages which failed maxDeliveries times to
+// topic, "" means <topic>-<subscription>-DLQ.
+func WithspDeadLetter(maxDeliveries int, topic string) SubscipOption {
+	return newfuncSubscripOption(func(opt *SubscriptionOpt) {
+		opt.deadLetter = &pb.DeadLetterPolicy{
+			MaxDeliveries: int32(maxDeliveries),
+			Topic:         topic,
+		}
+	})
+}
+
+// WithspNackBackoff redelivers a nacked message after min, doubled on each
+// nack of the same message up to max.
+func WithspNackBackoff(min, max time.Duration) SubscipOption {
+	return newfuncSubscripOption(func(opt *SubscriptionOpt) {
+		opt.nackBackoff = &pb.NackBackoff{
+			MinDelay: min.Milliseconds(),
+			MaxDelay: max.Milliseconds(),
+		}
+	})
+}
+
+// WithspAckTimeout redelivers the messages not acked within timeout, to
+// another consumer if the subscription has some.
+func WithspAckTimeout(timeout time.Duration) SubscipOption {
+	return newfuncSubscripOption(func(opt *SubscriptionOpt) {
+		opt.ackTimeout = timeout
+	})
+}
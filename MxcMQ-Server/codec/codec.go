@@ -0,0 +1,125 @@
+// Package codec compresses message payloads, the client keeps a copy of it.
+package codec
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+type CompressionType int32
+
+const (
+	Compression_None   CompressionType = 0
+	Compression_Gzip   CompressionType = 1
+	Compression_Snappy CompressionType = 2
+	Compression_Zstd   CompressionType = 3
+)
+
+// ErrTooLarge is returned for a payload which decompresses to more than the
+// limit it is read with.
+var ErrTooLarge = errors.New("decompressed payload too large")
+
+// zstd coders are safe for concurrent use and costly to create, a decoder is
+// kept for every limit
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoders   sync.Map // int -> *zstd.Decoder
+)
+
+func zstdDecoder(limit int) (*zstd.Decoder, error) {
+	if d, ok := zstdDecoders.Load(limit); ok {
+		return d.(*zstd.Decoder), nil
+	}
+	d, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(uint64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := zstdDecoders.LoadOrStore(limit, d)
+	return actual.(*zstd.Decoder), nil
+}
+
+func (c CompressionType) Valid() bool {
+	return c >= Compression_None && c <= Compression_Zstd
+}
+
+func Compress(c CompressionType, data []byte) ([]byte, error) {
+	switch c {
+	case Compression_None:
+		return data, nil
+	case Compression_Gzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case Compression_Snappy:
+		return snappy.Encode(nil, data), nil
+	case Compression_Zstd:
+		return zstdEncoder.EncodeAll(data, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown compression type: %v", c)
+	}
+}
+
+// Decompress returns data decompressed with c, or ErrTooLarge if that is
+// more than limit bytes. Payloads come from the network, they are never
+// decompressed further than limit.
+func Decompress(c CompressionType, data []byte, limit int) ([]byte, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("invalid decompression limit: %v", limit)
+	}
+	switch c {
+	case Compression_None:
+		if len(data) > limit {
+			return nil, ErrTooLarge
+		}
+		return data, nil
+	case Compression_Gzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		out, err := io.ReadAll(io.LimitReader(r, int64(limit)+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(out) > limit {
+			return nil, ErrTooLarge
+		}
+		return out, nil
+	case Compression_Snappy:
+		n, err := snappy.DecodedLen(data)
+		if err != nil {
+			return nil, err
+		}
+		if n > limit {
+			return nil, ErrTooLarge
+		}
+		return snappy.Decode(nil, data)
+	case Compression_Zstd:
+		d, err := zstdDecoder(limit)
+		if err != nil {
+			return nil, err
+		}
+		// a frame whose window is over limit is refused before decoding
+		out, err := d.DecodeAll(data, nil)
+		if errors.Is(err, zstd.ErrDecoderSizeExceeded) || errors.Is(err, zstd.ErrWindowSizeExceeded) || len(out) > limit {
+			return nil, ErrTooLarge
+		}
+		return out, err
+	default:
+		return nil, fmt.Errorf("unknown compression type: %v", c)
+	}
+}
@@ -0,0 +1,57 @@
+package codec
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+var codecs = []CompressionType{Compression_None, Compression_Gzip, Compression_Snappy, Compression_Zstd}
+
+func TestCompress(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"key":"value"}`), 100)
+	for _, c := range codecs {
+		compressed, err := Compress(c, data)
+		assert.Nil(t, err)
+		if c != Compression_None {
+			assert.Less(t, len(compressed), len(data))
+		}
+
+		got, err := Decompress(c, compressed, len(data))
+		assert.Nil(t, err)
+		assert.Equal(t, data, got)
+	}
+
+	_, err := Compress(CompressionType(9), data)
+	assert.NotNil(t, err)
+}
+
+func TestDecompressLimit(t *testing.T) {
+	// a small payload expanding to 1MB
+	data := make([]byte, 1<<20)
+	for _, c := range codecs {
+		compressed, err := Compress(c, data)
+		assert.Nil(t, err)
+
+		_, err = Decompress(c, compressed, len(data)-1)
+		assert.ErrorIs(t, err, ErrTooLarge, "codec %v", c)
+		got, err := Decompress(c, compressed, len(data))
+		assert.Nil(t, err, "codec %v", c)
+		assert.Len(t, got, len(data))
+	}
+
+	// a zstd stream does not tell its size up front
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	assert.Nil(t, err)
+	_, err = w.Write(data)
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+	_, err = Decompress(Compression_Zstd, buf.Bytes(), len(data)-1)
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	_, err = Decompress(Compression_Gzip, data, 0)
+	assert.NotNil(t, err)
+}
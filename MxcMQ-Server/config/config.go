@@ -13,6 +13,8 @@ var ZkConf = new(ZookeeperConf)
 
 var EConf = new(EtcdConf)
 
+var StConf = new(StoreConf)
+
 type ServerConf struct {
 	Name            string
 	Host            string
@@ -37,6 +39,17 @@ type ServerConf struct {
 
 	SyncWrite2disk     bool
 	AsyncWriteMsglimit int
+	MsgCacheBytes      int64
+	MaxMessageSize     int
+
+	RetentionCheckInterval   int
+	DefaultRetentionMaxAge   int64
+	DefaultRetentionMaxBytes int64
+	DefaultRetentionMaxMsgs  uint64
+	DefaultDeleteAcked       bool
+	CompactionInterval       int
+	QuotaHoldTimeout         int
+	DefaultMessageTTL        int64
 
 	IsLoadBalancerEnabled   bool
 	CollectLoadDataInterval int
@@ -45,6 +58,9 @@ type ServerConf struct {
 	DefaultNumberOfBundles int
 	DefaultMaxAddress      int
 
+	BrokerDeduplicationEnabled      bool
+	DeduplicationProducerInactivity int64
+
 	AllowRenameForClient bool
 
 	OperationRedoNum int
@@ -69,6 +85,23 @@ type EtcdConf struct {
 	DialTimeout int
 }
 
+type StoreConf struct {
+	Type string
+
+	// segment store
+	Dir                string
+	SegmentBytes       int64
+	IndexIntervalBytes int64
+
+	// offload
+	OffloadType      string
+	OffloadDir       string
+	OffloadRangeMsgs uint64
+
+	// encryption at rest
+	KeyringFile string
+}
+
 func GetConfig(path string) {
 	viper.SetConfigFile(path)
 	if err := viper.ReadInConfig(); err != nil {
@@ -86,5 +119,9 @@ func GetConfig(path string) {
 	if err := viper.UnmarshalKey("etcd", EConf); err != nil {
 		panic(fmt.Errorf("Unmarshal to EtcdConf failed, err: %v", err))
 	}
+
+	if err := viper.UnmarshalKey("store", StConf); err != nil {
+		panic(fmt.Errorf("Unmarshal to StoreConf failed, err: %v", err))
+	}
 	logger.Infof("load config: %v", path)
 }
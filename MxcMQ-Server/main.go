@@ -27,6 +27,11 @@ var (
 			persist.PersistInit()
 			rc.RcInit()
 
+			lock, err := lockData()
+			if err != nil {
+				return err
+			}
+			defer lock.Unlock()
 			server := server.NewServerFromConfig()
 			if err := server.Online(); err != nil {
 				logger.Errorf("Online failed: %v", err)
@@ -36,8 +41,136 @@ var (
 			return nil
 		},
 	}
+
+	cmdExport = cli.Command{
+		Name:  "export",
+		Usage: "export a topic to an archive file. For example: ./MxcMQ-Server export -c config/config.yaml -t topic -o topic.mqar",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "c",
+				Usage: "Load configuration from `FILE`",
+			},
+			&cli.StringFlag{
+				Name:     "t",
+				Usage:    "`TOPIC` to export",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "o",
+				Usage:    "Write the archive to `FILE`",
+				Required: true,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			config.GetConfig(c.String("c"))
+			persist.PersistInit()
+			rc.RcInit()
+
+			lock, err := lockData()
+			if err != nil {
+				return err
+			}
+			defer lock.Unlock()
+			f, err := os.Create(c.String("o"))
+			if err != nil {
+				return err
+			}
+			server := server.NewServerFromConfig()
+			defer server.ShutDown()
+			if err := server.ExportTopic(c.String("t"), f); err != nil {
+				f.Close()
+				os.Remove(f.Name())
+				return logger.Errorf("ExportTopic failed: %v", err)
+			}
+			return f.Close()
+		},
+	}
+
+	cmdImport = cli.Command{
+		Name:  "import",
+		Usage: "import a topic from an archive file. For example: ./MxcMQ-Server import -c config/config.yaml -i topic.mqar",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "c",
+				Usage: "Load configuration from `FILE`",
+			},
+			&cli.StringFlag{
+				Name:     "i",
+				Usage:    "Read the archive from `FILE`",
+				Required: true,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			config.GetConfig(c.String("c"))
+			persist.PersistInit()
+			rc.RcInit()
+
+			lock, err := lockData()
+			if err != nil {
+				return err
+			}
+			defer lock.Unlock()
+			f, err := os.Open(c.String("i"))
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			server := server.NewServerFromConfig()
+			defer server.ShutDown()
+			if _, err := server.ImportTopic(f); err != nil {
+				return logger.Errorf("ImportTopic failed: %v", err)
+			}
+			return nil
+		},
+	}
+
+	cmdReencrypt = cli.Command{
+		Name:  "reencrypt",
+		Usage: "re-encrypt the messages of topics with the active key of the keyring. For example: ./MxcMQ-Server reencrypt -c config/config.yaml -t topic",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "c",
+				Usage: "Load configuration from `FILE`",
+			},
+			&cli.StringSliceFlag{
+				Name:     "t",
+				Usage:    "`TOPIC` to re-encrypt, can be repeated",
+				Required: true,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			config.GetConfig(c.String("c"))
+			persist.PersistInit()
+			rc.RcInit()
+
+			// records are rewritten in place, never next to a running broker
+			lock, err := lockData()
+			if err != nil {
+				return err
+			}
+			defer lock.Unlock()
+			server := server.NewServerFromConfig()
+			defer server.ShutDown()
+			for _, topic := range c.StringSlice("t") {
+				if err := server.Reencrypt(topic); err != nil {
+					return logger.Errorf("Reencrypt %v failed: %v", topic, err)
+				}
+			}
+			return nil
+		},
+	}
 )
 
+// lockData takes the data dirs for the running command, a broker holds them
+// as long as it runs.
+func lockData() (*persist.DataLock, error) {
+	lock, err := persist.LockData()
+	if err != nil {
+		return nil, logger.Errorf("LockData failed, is the broker running? %v", err)
+	}
+	return lock, nil
+}
+
 func main() {
 	app := newapp(&cmdStart)
 	_ = app.Run(os.Args)
@@ -52,6 +185,9 @@ func newapp(startCmd *cli.Command) *cli.App {
 
 	app.Commands = []*cli.Command{
 		&cmdStart,
+		&cmdExport,
+		&cmdImport,
+		&cmdReencrypt,
 	}
 
 	app.Action = func(c *cli.Context) error {
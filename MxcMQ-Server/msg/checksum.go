@@ -0,0 +1,23 @@
+package msg
+
+import (
+	"errors"
+	"hash/crc32"
+)
+
+var ErrCorruptMsg = errors.New("corrupt message")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Checksum returns the crc32c of payload.
+func Checksum(payload []byte) uint32 {
+	return crc32.Checksum(payload, crcTable)
+}
+
+// Verify checks Payload against Crc, messages stored without a crc pass.
+func (m *MsgData) Verify() error {
+	if m.HasCrc && Checksum(m.Payload) != m.Crc {
+		return ErrCorruptMsg
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package msg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	m := &MsgData{Payload: []byte("payload")}
+	assert.Nil(t, m.Verify())
+
+	m.Crc, m.HasCrc = Checksum(m.Payload), true
+	assert.Nil(t, m.Verify())
+
+	// a crc of 0 is checked like any other
+	m.Crc = 0
+	assert.Equal(t, ErrCorruptMsg, m.Verify())
+	m.Crc = Checksum(m.Payload)
+
+	m.Payload = []byte("paylaod")
+	assert.Equal(t, ErrCorruptMsg, m.Verify())
+}
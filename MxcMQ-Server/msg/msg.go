@@ -1,6 +1,7 @@
 package msg
 
 import (
+	"MxcMQ-Server/codec"
 	"MxcMQ-Server/logger"
 	"time"
 )
@@ -27,9 +28,20 @@ type PullArg struct {
 }
 
 type MsgData struct {
-	Msid    uint64
-	Mid     int64
-	Payload string
+	Msid         uint64
+	Mid          int64
+	Key          string
+	Payload      []byte
+	PublishTime  int64 // unix millisecond
+	EventTime    int64 // unix millisecond, given by the producer, 0 if unset
+	DeliverAt    int64 // unix millisecond, hidden from subscribers until then, 0 if unset
+	ExpireAt     int64 // unix millisecond, from the ttl of the message, 0 if unset
+	ProducerName string
+	SequenceId   int64 // given by the producer to deduplicate publishes, 0 if unset
+	Properties   map[string]string
+	Compression  codec.CompressionType // of Payload
+	Crc          uint32                // crc32c of Payload if HasCrc
+	HasCrc       bool                  // false for messages stored before checksums
 }
 
 func (pa *PullArg) CheckTimeout(timeout int) {
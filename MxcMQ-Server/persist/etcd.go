@@ -3,13 +3,35 @@ package persist
 import (
 	"MxcMQ-Server/config"
 	"MxcMQ-Server/logger"
+	"MxcMQ-Server/msg"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 var EtcdCli *clientv3.Client
 
+const (
+	// msids are padded so that the keys of a partition sort by msid
+	msgKey       = "/%s/p%d/%020d" // topic/partition/msid
+	partitionKey = "/%s/p%d"       //topic/partition
+
+	// brokers before padded msids stored messages there, see upgrade
+	legacyMsgKey = "/%s/p%d/%d"
+	upgradedKey  = "/%s/p%d/.padded"
+
+	// etcd rejects txns with more ops than --max-txn-ops (128 by default)
+	maxTxnOps = 128
+)
+
 func PersistInit() {
 	config := clientv3.Config{
 		Endpoints:   config.EConf.Endpoints,
@@ -23,4 +45,250 @@ func PersistInit() {
 	logger.Infoln("etcd init over")
 }
 
-// operations include put/get/... are implemented in broker.
+// etcdStore keeps every message as an encoded record under its own msgKey.
+type etcdStore struct {
+	kv       clientv3.KV
+	upgraded sync.Map // partitions without legacy keys
+}
+
+func NewEtcdStore(cli *clientv3.Client) MessageStore {
+	return newEtcdStore(clientv3.NewKV(cli))
+}
+
+func newEtcdStore(kv clientv3.KV) *etcdStore {
+	return &etcdStore{kv: kv}
+}
+
+// msgRange returns the key range of the messages of a partition whose msid
+// is in [start, end].
+func msgRange(topic string, partition int, start, end uint64) (string, string) {
+	return fmt.Sprintf(msgKey, topic, partition, start), fmt.Sprintf(msgKey, topic, partition, end) + "\x00"
+}
+
+func (s *etcdStore) Append(topic string, partition int, msgs ...*msg.MsgData) error {
+	if err := s.upgrade(topic, partition); err != nil {
+		return err
+	}
+	ops := make([]clientv3.Op, 0, len(msgs))
+	for _, m := range msgs {
+		data, err := encodeMsg(m)
+		if err != nil {
+			return err
+		}
+		key := fmt.Sprintf(msgKey, topic, partition, m.Msid)
+		ops = append(ops, clientv3.OpPut(key, string(data)))
+	}
+	return s.txn(ops)
+}
+
+func (s *etcdStore) Read(topic string, partition int, start, end uint64) ([]*msg.MsgData, error) {
+	if end < start {
+		return nil, nil
+	}
+	if err := s.upgrade(topic, partition); err != nil {
+		return nil, err
+	}
+	var msgs []*msg.MsgData
+	err := s.scan(topic, partition, start, end, func(kv *mvccpb.KeyValue) error {
+		m, err := decodeMsg(kv.Value)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, m)
+		return nil
+	})
+	return msgs, err
+}
+
+// scan calls fn on the messages of a partition whose msid is in [start, end],
+// a page at a time.
+func (s *etcdStore) scan(topic string, partition int, start, end uint64, fn func(kv *mvccpb.KeyValue) error) error {
+	key, rangeEnd := msgRange(topic, partition, start, end)
+	for {
+		resp, err := s.kv.Get(context.TODO(), key, clientv3.WithRange(rangeEnd), clientv3.WithLimit(maxTxnOps))
+		if err != nil {
+			return err
+		}
+		for _, kv := range resp.Kvs {
+			if err := fn(kv); err != nil {
+				return err
+			}
+		}
+		if !resp.More || len(resp.Kvs) == 0 {
+			return nil
+		}
+		key = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+}
+
+func (s *etcdStore) Delete(topic string, partition int, start, end uint64) error {
+	if end < start {
+		return nil
+	}
+	if err := s.upgrade(topic, partition); err != nil {
+		return err
+	}
+	key, rangeEnd := msgRange(topic, partition, start, end)
+	_, err := s.kv.Delete(context.TODO(), key, clientv3.WithRange(rangeEnd))
+	return err
+}
+
+func (s *etcdStore) Remove(topic string, partition int, msids ...uint64) error {
+	if err := s.upgrade(topic, partition); err != nil {
+		return err
+	}
+	ops := make([]clientv3.Op, 0, len(msids))
+	for _, msid := range msids {
+		ops = append(ops, clientv3.OpDelete(fmt.Sprintf(msgKey, topic, partition, msid)))
+	}
+	return s.txn(ops)
+}
+
+func (s *etcdStore) Truncate(topic string, partition int, from uint64) error {
+	if err := s.upgrade(topic, partition); err != nil {
+		return err
+	}
+	key, rangeEnd := msgRange(topic, partition, from, math.MaxUint64)
+	_, err := s.kv.Delete(context.TODO(), key, clientv3.WithRange(rangeEnd))
+	return err
+}
+
+func (s *etcdStore) Rewrite(topic string, partition int) (int, error) {
+	if err := s.upgrade(topic, partition); err != nil {
+		return 0, err
+	}
+	n := 0
+	err := s.scan(topic, partition, 0, math.MaxUint64, func(kv *mvccpb.KeyValue) error {
+		if !stale(kv.Value) {
+			return nil
+		}
+		data, err := recode(kv.Value)
+		if err != nil {
+			return fmt.Errorf("rewrite %s: %w", kv.Key, err)
+		}
+		// skip messages deleted or rewritten in the meantime
+		cmp := clientv3.Compare(clientv3.ModRevision(string(kv.Key)), "=", kv.ModRevision)
+		tresp, err := s.kv.Txn(context.TODO()).If(cmp).Then(clientv3.OpPut(string(kv.Key), string(data))).Commit()
+		if err != nil {
+			return err
+		}
+		if tresp.Succeeded {
+			n++
+		}
+		return nil
+	})
+	return n, err
+}
+
+func (s *etcdStore) LastOffset(topic string, partition int) (uint64, error) {
+	if err := s.upgrade(topic, partition); err != nil {
+		return 0, err
+	}
+	key, rangeEnd := msgRange(topic, partition, 0, math.MaxUint64)
+	resp, err := s.kv.Get(context.TODO(), key, clientv3.WithRange(rangeEnd), clientv3.WithKeysOnly(),
+		clientv3.WithSort(clientv3.SortByKey, clientv3.SortDescend), clientv3.WithLimit(1))
+	if err != nil || len(resp.Kvs) == 0 {
+		return 0, err
+	}
+	prefix := fmt.Sprintf(partitionKey, topic, partition) + "/"
+	return strconv.ParseUint(strings.TrimPrefix(string(resp.Kvs[0].Key), prefix), 10, 64)
+}
+
+// upgrade moves the messages a partition holds under legacyMsgKey to msgKey,
+// the first time the partition is used. upgradedKey marks it done.
+func (s *etcdStore) upgrade(topic string, partition int) error {
+	name := fmt.Sprintf(partitionKey, topic, partition)
+	if _, ok := s.upgraded.Load(name); ok {
+		return nil
+	}
+	marker := fmt.Sprintf(upgradedKey, topic, partition)
+	resp, err := s.kv.Get(context.TODO(), marker, clientv3.WithCountOnly())
+	if err != nil {
+		return err
+	}
+	if resp.Count == 0 {
+		if err := s.moveLegacy(topic, partition); err != nil {
+			return err
+		}
+		if _, err := s.kv.Put(context.TODO(), marker, ""); err != nil {
+			return err
+		}
+	}
+	s.upgraded.Store(name, true)
+	return nil
+}
+
+func (s *etcdStore) moveLegacy(topic string, partition int) error {
+	prefix := fmt.Sprintf(partitionKey, topic, partition) + "/"
+	end := clientv3.GetPrefixRangeEnd(prefix)
+	for key := prefix; ; {
+		resp, err := s.kv.Get(context.TODO(), key, clientv3.WithRange(end), clientv3.WithLimit(maxTxnOps))
+		if err != nil {
+			return err
+		}
+		for _, kv := range resp.Kvs {
+			// subscriptions share the prefix, only numeric keys are messages
+			msid, err := strconv.ParseUint(strings.TrimPrefix(string(kv.Key), prefix), 10, 64)
+			if err != nil || string(kv.Key) != fmt.Sprintf(legacyMsgKey, topic, partition, msid) {
+				continue
+			}
+			cmp := clientv3.Compare(clientv3.ModRevision(string(kv.Key)), "=", kv.ModRevision)
+			put := clientv3.OpPut(fmt.Sprintf(msgKey, topic, partition, msid), string(kv.Value))
+			if _, err := s.kv.Txn(context.TODO()).If(cmp).Then(put, clientv3.OpDelete(string(kv.Key))).Commit(); err != nil {
+				return err
+			}
+		}
+		if !resp.More || len(resp.Kvs) == 0 {
+			return nil
+		}
+		key = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+}
+
+func (s *etcdStore) Close() error {
+	return nil
+}
+
+func (s *etcdStore) txn(ops []clientv3.Op) error {
+	for len(ops) > 0 {
+		n := len(ops)
+		if n > maxTxnOps {
+			n = maxTxnOps
+		}
+		if _, err := s.kv.Txn(context.TODO()).Then(ops[:n]...).Commit(); err != nil {
+			return err
+		}
+		ops = ops[n:]
+	}
+	return nil
+}
+
+// etcdObjectStore keeps every object as a value under its name.
+type etcdObjectStore struct {
+	kv clientv3.KV
+}
+
+func NewEtcdObjectStore(cli *clientv3.Client) ObjectStore {
+	return &etcdObjectStore{kv: clientv3.NewKV(cli)}
+}
+
+func (s *etcdObjectStore) Put(name string, data []byte) error {
+	_, err := s.kv.Put(context.TODO(), name, string(data))
+	return err
+}
+
+func (s *etcdObjectStore) Get(name string) ([]byte, error) {
+	resp, err := s.kv.Get(context.TODO(), name)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, fmt.Errorf("object %v: %w", name, os.ErrNotExist)
+	}
+	return resp.Kvs[0].Value, nil
+}
+
+func (s *etcdObjectStore) Delete(name string) error {
+	_, err := s.kv.Delete(context.TODO(), name)
+	return err
+}
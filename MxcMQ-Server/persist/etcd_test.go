@@ -1,13 +1,22 @@
 package persist
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
 	"testing"
 
 	"MxcMQ-Server/config"
+	"MxcMQ-Server/msg"
 
 	"github.com/stretchr/testify/assert"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/grpc"
 )
 
 func TestPut(t *testing.T) {
@@ -23,4 +32,195 @@ func TestPut(t *testing.T) {
 	resp, err := kv.Get(context.TODO(), key)
 	assert.Nil(t, err)
 	assert.Equal(t, val, string(resp.Kvs[0].Value))
-}
\ No newline at end of file
+}
+
+// fakeKV serves the etcd kv api from memory, with the range, limit and sort
+// options and the mod revision compares the stores use.
+type fakeKV struct {
+	mu     sync.Mutex
+	rev    int64
+	kvs    map[string]*mvccpb.KeyValue
+	ranges []*pb.RangeRequest
+}
+
+func newFakeKV() *fakeKV {
+	return &fakeKV{kvs: make(map[string]*mvccpb.KeyValue)}
+}
+
+// inRange tells if key is in the range of a request, the way etcd does.
+func inRange(key, start, end []byte) bool {
+	switch {
+	case len(end) == 0:
+		return bytes.Equal(key, start)
+	case bytes.Equal(end, []byte{0}):
+		return bytes.Compare(key, start) >= 0
+	default:
+		return bytes.Compare(key, start) >= 0 && bytes.Compare(key, end) < 0
+	}
+}
+
+func (f *fakeKV) match(start, end []byte) []*mvccpb.KeyValue {
+	var kvs []*mvccpb.KeyValue
+	for _, kv := range f.kvs {
+		if inRange(kv.Key, start, end) {
+			kvs = append(kvs, kv)
+		}
+	}
+	sort.Slice(kvs, func(i, j int) bool { return bytes.Compare(kvs[i].Key, kvs[j].Key) < 0 })
+	return kvs
+}
+
+func (f *fakeKV) rangeLocked(in *pb.RangeRequest) *pb.RangeResponse {
+	f.ranges = append(f.ranges, in)
+	kvs := f.match(in.Key, in.RangeEnd)
+	resp := &pb.RangeResponse{Count: int64(len(kvs))}
+	if in.CountOnly {
+		return resp
+	}
+	if in.SortOrder == pb.RangeRequest_DESCEND {
+		for i, j := 0, len(kvs)-1; i < j; i, j = i+1, j-1 {
+			kvs[i], kvs[j] = kvs[j], kvs[i]
+		}
+	}
+	if in.Limit > 0 && int64(len(kvs)) > in.Limit {
+		kvs, resp.More = kvs[:in.Limit], true
+	}
+	for _, kv := range kvs {
+		c := *kv
+		if in.KeysOnly {
+			c.Value = nil
+		}
+		resp.Kvs = append(resp.Kvs, &c)
+	}
+	return resp
+}
+
+func (f *fakeKV) putLocked(in *pb.PutRequest) *pb.PutResponse {
+	f.rev++
+	f.kvs[string(in.Key)] = &mvccpb.KeyValue{Key: in.Key, Value: in.Value, ModRevision: f.rev}
+	return &pb.PutResponse{}
+}
+
+func (f *fakeKV) deleteLocked(in *pb.DeleteRangeRequest) *pb.DeleteRangeResponse {
+	kvs := f.match(in.Key, in.RangeEnd)
+	for _, kv := range kvs {
+		delete(f.kvs, string(kv.Key))
+	}
+	f.rev++
+	return &pb.DeleteRangeResponse{Deleted: int64(len(kvs))}
+}
+
+func (f *fakeKV) Range(ctx context.Context, in *pb.RangeRequest, opts ...grpc.CallOption) (*pb.RangeResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rangeLocked(in), nil
+}
+
+func (f *fakeKV) Put(ctx context.Context, in *pb.PutRequest, opts ...grpc.CallOption) (*pb.PutResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.putLocked(in), nil
+}
+
+func (f *fakeKV) DeleteRange(ctx context.Context, in *pb.DeleteRangeRequest, opts ...grpc.CallOption) (*pb.DeleteRangeResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.deleteLocked(in), nil
+}
+
+func (f *fakeKV) Txn(ctx context.Context, in *pb.TxnRequest, opts ...grpc.CallOption) (*pb.TxnResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resp := &pb.TxnResponse{Succeeded: true}
+	for _, cmp := range in.Compare {
+		var rev int64
+		if kv, ok := f.kvs[string(cmp.Key)]; ok {
+			rev = kv.ModRevision
+		}
+		if cmp.Target != pb.Compare_MOD || cmp.Result != pb.Compare_EQUAL {
+			return nil, errors.New("unsupported compare")
+		}
+		if rev != cmp.GetModRevision() {
+			resp.Succeeded = false
+		}
+	}
+	ops := in.Success
+	if !resp.Succeeded {
+		ops = in.Failure
+	}
+	for _, op := range ops {
+		switch r := op.Request.(type) {
+		case *pb.RequestOp_RequestRange:
+			f.rangeLocked(r.RequestRange)
+		case *pb.RequestOp_RequestPut:
+			f.putLocked(r.RequestPut)
+		case *pb.RequestOp_RequestDeleteRange:
+			f.deleteLocked(r.RequestDeleteRange)
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeKV) Compact(ctx context.Context, in *pb.CompactionRequest, opts ...grpc.CallOption) (*pb.CompactionResponse, error) {
+	return &pb.CompactionResponse{}, nil
+}
+
+func TestEtcdStore(t *testing.T) {
+	fake := newFakeKV()
+	kv := clientv3.NewKVFromKVClient(fake, nil)
+	topic, partition := "t", 1
+
+	// a message and a subscription stored before msids were padded
+	data, err := encodeMsg(&msg.MsgData{Msid: 9, Payload: []byte("m9")})
+	assert.Nil(t, err)
+	_, err = kv.Put(context.TODO(), fmt.Sprintf(legacyMsgKey, topic, partition, 9), string(data))
+	assert.Nil(t, err)
+	_, err = kv.Put(context.TODO(), "/t/p1/s1", "{}")
+	assert.Nil(t, err)
+
+	s := newEtcdStore(kv)
+	var msgs []*msg.MsgData
+	for i := 10; i <= 300; i++ {
+		msgs = append(msgs, &msg.MsgData{Msid: uint64(i), Payload: []byte(fmt.Sprintf("m%v", i))})
+	}
+	assert.Nil(t, s.Append(topic, partition, msgs...))
+	_, err = kv.Get(context.TODO(), fmt.Sprintf(upgradedKey, topic, partition))
+	assert.Nil(t, err)
+
+	fake.ranges = nil
+	last, err := s.LastOffset(topic, partition)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(300), last)
+	assert.Equal(t, 1, len(fake.ranges))
+	assert.Equal(t, int64(1), fake.ranges[0].Limit)
+
+	// 9 and 10 sort before 100 although their legacy keys did not
+	read, err := s.Read(topic, partition, 9, 300)
+	assert.Nil(t, err)
+	assert.Equal(t, 292, len(read))
+	assert.Equal(t, "m9", string(read[0].Payload))
+	assert.Equal(t, uint64(100), read[91].Msid)
+	assert.Equal(t, uint64(300), read[291].Msid)
+
+	fake.ranges = nil
+	assert.Nil(t, s.Delete(topic, partition, 9, 199))
+	assert.Empty(t, fake.ranges)
+	read, err = s.Read(topic, partition, 1, 250)
+	assert.Nil(t, err)
+	assert.Equal(t, 51, len(read))
+	assert.Equal(t, uint64(200), read[0].Msid)
+
+	assert.Nil(t, s.Truncate(topic, partition, 251))
+	last, err = s.LastOffset(topic, partition)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(250), last)
+
+	// the subscription is not a message
+	resp, err := kv.Get(context.TODO(), "/t/p1/s1")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(resp.Kvs))
+
+	last, err = s.LastOffset(topic, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), last)
+}
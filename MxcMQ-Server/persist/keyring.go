@@ -0,0 +1,111 @@
+package persist
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrUnknownKey is returned when a record is encrypted with a key which is
+// not in the keyring.
+var ErrUnknownKey = errors.New("unknown encryption key")
+
+// keyring encrypts the payloads of new records, nil means plain text.
+var keyring *Keyring
+
+// Keyring holds the AES keys payloads are encrypted with at rest. It is read
+// from a json file:
+//
+//	{"Active": 2, "Keys": {"1": "<base64 key>", "2": "<base64 key>"}}
+//
+// New records use the Active key, the others are kept to read old records
+// until they are re-encrypted.
+type Keyring struct {
+	Active uint32
+	Keys   map[uint32][]byte // 16, 24 or 32 bytes for AES-128/192/256
+
+	aeads map[uint32]cipher.AEAD
+}
+
+func LoadKeyring(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	k := &Keyring{}
+	if err := json.Unmarshal(data, k); err != nil {
+		return nil, fmt.Errorf("parse keyring %v: %v", path, err)
+	}
+	if err := k.init(); err != nil {
+		return nil, fmt.Errorf("keyring %v: %v", path, err)
+	}
+	return k, nil
+}
+
+func (k *Keyring) init() error {
+	if _, ok := k.Keys[k.Active]; !ok {
+		return fmt.Errorf("active key %v is not in the keyring", k.Active)
+	}
+	k.aeads = make(map[uint32]cipher.AEAD, len(k.Keys))
+	for id, key := range k.Keys {
+		// 0 marks plain text records
+		if id == 0 {
+			return errors.New("key id 0 is reserved")
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return fmt.Errorf("key %v: %v", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return fmt.Errorf("key %v: %v", id, err)
+		}
+		k.aeads[id] = aead
+	}
+	return nil
+}
+
+// SetKeyring makes new records encrypted with k, nil turns encryption off.
+func SetKeyring(k *Keyring) {
+	keyring = k
+}
+
+// activeKey returns the id of the key new records are encrypted with.
+func activeKey() uint32 {
+	if keyring == nil {
+		return 0
+	}
+	return keyring.Active
+}
+
+// seal returns nonce | ciphertext of payload under the active key, the msid
+// is authenticated so records can not be swapped.
+func (k *Keyring) seal(msid uint64, payload []byte) ([]byte, error) {
+	aead := k.aeads[k.Active]
+	out := make([]byte, aead.NonceSize(), aead.NonceSize()+len(payload)+aead.Overhead())
+	if _, err := rand.Read(out); err != nil {
+		return nil, err
+	}
+	return aead.Seal(out, out, payload, binary.BigEndian.AppendUint64(nil, msid)), nil
+}
+
+func (k *Keyring) open(id uint32, msid uint64, data []byte) ([]byte, error) {
+	aead, ok := k.aeads[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownKey, id)
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errShortRecord
+	}
+	nonce, sealed := data[:aead.NonceSize()], data[aead.NonceSize():]
+	payload, err := aead.Open(nil, nonce, sealed, binary.BigEndian.AppendUint64(nil, msid))
+	if err != nil {
+		return nil, ErrCorruptRecord
+	}
+	return payload, nil
+}
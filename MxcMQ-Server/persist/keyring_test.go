@@ -0,0 +1,72 @@
+package persist
+
+import (
+	"MxcMQ-Server/msg"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testKeyring(t *testing.T, active uint32, ids ...uint32) *Keyring {
+	k := &Keyring{Active: active, Keys: make(map[uint32][]byte)}
+	for _, id := range ids {
+		k.Keys[id] = bytes.Repeat([]byte{byte(id)}, 32)
+	}
+	assert.Nil(t, k.init())
+	return k
+}
+
+func TestKeyringRecord(t *testing.T) {
+	defer SetKeyring(nil)
+	plain, err := encodeMsg(&msg.MsgData{Msid: 1, Payload: []byte("secret payload")})
+	assert.Nil(t, err)
+
+	SetKeyring(testKeyring(t, 1, 1))
+	m := &msg.MsgData{Msid: 1, Key: "k", Payload: []byte("secret payload")}
+	b, err := encodeMsg(m)
+	assert.Nil(t, err)
+	assert.False(t, bytes.Contains(b, m.Payload))
+	assert.True(t, stale(plain))
+	assert.False(t, stale(b))
+
+	got, err := decodeMsg(b)
+	assert.Nil(t, err)
+	assert.Equal(t, m, got)
+	got, err = decodeMsg(plain)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret payload", string(got.Payload))
+
+	// rotate
+	SetKeyring(testKeyring(t, 2, 1, 2))
+	assert.True(t, stale(b))
+	b2, err := recode(b)
+	assert.Nil(t, err)
+	assert.False(t, stale(b2))
+
+	SetKeyring(testKeyring(t, 2, 2))
+	_, err = decodeMsg(b)
+	assert.True(t, errors.Is(err, ErrUnknownKey))
+	got, err = decodeMsg(b2)
+	assert.Nil(t, err)
+	assert.Equal(t, m, got)
+
+	// a payload moved to another msid does not open
+	_, err = keyring.open(2, 2, b2[len(b2)-len(m.Payload)-28:])
+	assert.Equal(t, ErrCorruptRecord, err)
+}
+
+func TestLoadKeyring(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	assert.Nil(t, os.WriteFile(path, []byte(`{"Active": 1, "Keys": {"1": "MDEyMzQ1Njc4OWFiY2RlZg=="}}`), 0600))
+	k, err := LoadKeyring(path)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("0123456789abcdef"), k.Keys[1])
+
+	assert.Nil(t, os.WriteFile(path, []byte(`{"Active": 2, "Keys": {"1": "MDEyMzQ1Njc4OWFiY2RlZg=="}}`), 0600))
+	_, err = LoadKeyring(path)
+	assert.NotNil(t, err)
+}
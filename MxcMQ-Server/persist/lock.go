@@ -0,0 +1,79 @@
+package persist
+
+import (
+	"MxcMQ-Server/config"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// name of the file flocked in every locked dir
+const lockFile = ".lock"
+
+// ErrLocked is returned by LockData while another process, a running broker
+// or a command, holds the data dirs.
+var ErrLocked = errors.New("data dir is in use by another process")
+
+// DataLock holds exclusive flocks on the dirs of the stores.
+type DataLock struct {
+	files []*os.File
+}
+
+// LockData locks the dirs the configured stores keep files in: the segment
+// store dir and the file offload dir. The broker holds it while it runs, so
+// commands rewriting those files refuse to run next to it. etcd has no local
+// dir, its writes compare revisions instead.
+func LockData() (*DataLock, error) {
+	var dirs []string
+	if config.StConf.Type == StoreSegment {
+		dirs = append(dirs, filepath.Clean(config.StConf.Dir))
+	}
+	if config.StConf.OffloadType == ObjectFile {
+		if dir := filepath.Clean(config.StConf.OffloadDir); len(dirs) == 0 || dirs[0] != dir {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	l := &DataLock{}
+	for _, dir := range dirs {
+		f, err := lockDir(dir)
+		if err != nil {
+			l.Unlock()
+			return nil, err
+		}
+		l.files = append(l.files, f)
+	}
+	return l, nil
+}
+
+func lockDir(dir string) (*os.File, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, lockFile), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, fmt.Errorf("%v: %w", dir, ErrLocked)
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+// Unlock releases the dirs, the flocks go with the files.
+func (l *DataLock) Unlock() error {
+	var err error
+	for _, f := range l.files {
+		if e := f.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	l.files = nil
+	return err
+}
@@ -0,0 +1,39 @@
+package persist
+
+import (
+	"MxcMQ-Server/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockData(t *testing.T) {
+	defer func() {
+		config.StConf.Type, config.StConf.Dir = "", ""
+		config.StConf.OffloadType, config.StConf.OffloadDir = "", ""
+	}()
+	config.StConf.Type, config.StConf.Dir = StoreSegment, t.TempDir()
+	config.StConf.OffloadType, config.StConf.OffloadDir = ObjectFile, t.TempDir()
+
+	l, err := LockData()
+	assert.Nil(t, err)
+	_, err = LockData()
+	assert.ErrorIs(t, err, ErrLocked)
+
+	// the offload dir alone is enough to refuse
+	config.StConf.Type = StoreEtcd
+	_, err = LockData()
+	assert.ErrorIs(t, err, ErrLocked)
+
+	assert.Nil(t, l.Unlock())
+	l, err = LockData()
+	assert.Nil(t, err)
+	assert.Nil(t, l.Unlock())
+
+	// a single dir for both is locked once
+	config.StConf.Type = StoreSegment
+	config.StConf.OffloadDir = config.StConf.Dir
+	l, err = LockData()
+	assert.Nil(t, err)
+	assert.Nil(t, l.Unlock())
+}
@@ -0,0 +1,165 @@
+package persist
+
+import (
+	"MxcMQ-Server/msg"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// memoryStore keeps messages in process memory, it is meant for tests.
+type memoryStore struct {
+	mu         sync.RWMutex
+	partitions map[string]*memoryPartition
+}
+
+type memoryPartition struct {
+	msgs map[uint64]*msg.MsgData
+	last uint64
+}
+
+func NewMemoryStore() MessageStore {
+	return &memoryStore{
+		partitions: make(map[string]*memoryPartition),
+	}
+}
+
+func (s *memoryStore) partition(topic string, partition int, create bool) *memoryPartition {
+	name := fmt.Sprintf(partitionKey, topic, partition)
+	p, ok := s.partitions[name]
+	if !ok && create {
+		p = &memoryPartition{msgs: make(map[uint64]*msg.MsgData)}
+		s.partitions[name] = p
+	}
+	return p
+}
+
+func (s *memoryStore) Append(topic string, partition int, msgs ...*msg.MsgData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.partition(topic, partition, true)
+	for _, m := range msgs {
+		cp := *m
+		p.msgs[m.Msid] = &cp
+		if m.Msid > p.last {
+			p.last = m.Msid
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) Read(topic string, partition int, start, end uint64) ([]*msg.MsgData, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var msgs []*msg.MsgData
+	p := s.partition(topic, partition, false)
+	if p == nil {
+		return msgs, nil
+	}
+	for i := start; i <= end && i <= p.last; i++ {
+		if m, ok := p.msgs[i]; ok {
+			cp := *m
+			msgs = append(msgs, &cp)
+		}
+	}
+	return msgs, nil
+}
+
+func (s *memoryStore) Delete(topic string, partition int, start, end uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.partition(topic, partition, false)
+	if p == nil {
+		return nil
+	}
+	for i := start; i <= end && i <= p.last; i++ {
+		delete(p.msgs, i)
+	}
+	return nil
+}
+
+func (s *memoryStore) Remove(topic string, partition int, msids ...uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.partition(topic, partition, false)
+	if p == nil {
+		return nil
+	}
+	for _, msid := range msids {
+		delete(p.msgs, msid)
+	}
+	return nil
+}
+
+func (s *memoryStore) Truncate(topic string, partition int, from uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.partition(topic, partition, false)
+	if p == nil || p.last < from {
+		return nil
+	}
+	for i := from; i <= p.last; i++ {
+		delete(p.msgs, i)
+	}
+	p.last = from - 1
+	return nil
+}
+
+// Rewrite has nothing to do, messages are not encoded in memory.
+func (s *memoryStore) Rewrite(topic string, partition int) (int, error) {
+	return 0, nil
+}
+
+func (s *memoryStore) LastOffset(topic string, partition int) (uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p := s.partition(topic, partition, false)
+	if p == nil {
+		return 0, nil
+	}
+	return p.last, nil
+}
+
+func (s *memoryStore) Close() error {
+	return nil
+}
+
+// memoryObjectStore keeps objects in process memory, it is meant for tests.
+type memoryObjectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func NewMemoryObjectStore() ObjectStore {
+	return &memoryObjectStore{objects: make(map[string][]byte)}
+}
+
+func (s *memoryObjectStore) Put(name string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[name] = append([]byte(nil), data...)
+	return nil
+}
+
+func (s *memoryObjectStore) Get(name string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.objects[name]
+	if !ok {
+		return nil, fmt.Errorf("object %v: %w", name, os.ErrNotExist)
+	}
+	return data, nil
+}
+
+func (s *memoryObjectStore) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, name)
+	return nil
+}
@@ -0,0 +1,54 @@
+package persist
+
+import (
+	"MxcMQ-Server/msg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemoryStore()
+	topic := "testTopic"
+	partition := 1
+
+	last, err := s.LastOffset(topic, partition)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), last)
+
+	var msgs []*msg.MsgData
+	for i := 1; i <= 10; i++ {
+		msgs = append(msgs, &msg.MsgData{Msid: uint64(i), Mid: int64(i), Payload: []byte("testPayload")})
+	}
+	err = s.Append(topic, partition, msgs...)
+	assert.Nil(t, err)
+
+	last, err = s.LastOffset(topic, partition)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), last)
+
+	data, err := s.Read(topic, partition, 3, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(data))
+	assert.Equal(t, uint64(3), data[0].Msid)
+	assert.Equal(t, []byte("testPayload"), data[2].Payload)
+
+	err = s.Delete(topic, partition, 1, 4)
+	assert.Nil(t, err)
+	data, err = s.Read(topic, partition, 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(data))
+	assert.Equal(t, uint64(5), data[0].Msid)
+
+	assert.Nil(t, s.Truncate(topic, partition, 8))
+	last, err = s.LastOffset(topic, partition)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), last)
+	data, err = s.Read(topic, partition, 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(data))
+
+	data, err = s.Read(topic, partition+1, 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(data))
+}
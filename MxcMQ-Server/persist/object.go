@@ -0,0 +1,116 @@
+package persist
+
+import (
+	"MxcMQ-Server/config"
+	"MxcMQ-Server/msg"
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const ObjectFile = "file"
+
+// ObjectStore keeps immutable blobs by name, it is where cold messages are
+// offloaded to.
+type ObjectStore interface {
+	Put(name string, data []byte) error
+	Get(name string) ([]byte, error)
+	Delete(name string) error
+}
+
+// NewObjectStore returns nil if offloading is not configured.
+func NewObjectStore() (ObjectStore, error) {
+	switch config.StConf.OffloadType {
+	case "":
+		return nil, nil
+	case ObjectFile:
+		return NewFileObjectStore(config.StConf.OffloadDir)
+	default:
+		return nil, fmt.Errorf("unknown object store type: %v", config.StConf.OffloadType)
+	}
+}
+
+// fileObjectStore keeps every object as a file under dir.
+type fileObjectStore struct {
+	dir string
+}
+
+func NewFileObjectStore(dir string) (ObjectStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &fileObjectStore{dir: dir}, nil
+}
+
+func (s *fileObjectStore) path(name string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(name))
+}
+
+func (s *fileObjectStore) Put(name string, data []byte) error {
+	path := s.path(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *fileObjectStore) Get(name string) ([]byte, error) {
+	return os.ReadFile(s.path(name))
+}
+
+func (s *fileObjectStore) Delete(name string) error {
+	err := os.Remove(s.path(name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// EncodeRange packs msgs into one blob, in the record format of segments.
+func EncodeRange(msgs []*msg.MsgData) ([]byte, error) {
+	var buf []byte
+	for _, m := range msgs {
+		rec, err := encodeRecord(m)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, rec...)
+	}
+	return buf, nil
+}
+
+func DecodeRange(data []byte) ([]*msg.MsgData, error) {
+	var msgs []*msg.MsgData
+	r := bufio.NewReader(bytes.NewReader(data))
+	for pos := int64(0); pos < int64(len(data)); {
+		_, n, body, err := readRecord(r, int64(len(data))-pos)
+		if err != nil {
+			return nil, fmt.Errorf("read range at %v: %w", pos, err)
+		}
+		pos += n
+		m, err := decodeMsg(body)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, m)
+	}
+	return msgs, nil
+}
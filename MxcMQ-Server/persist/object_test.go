@@ -0,0 +1,58 @@
+package persist
+
+import (
+	"MxcMQ-Server/config"
+	"MxcMQ-Server/msg"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileObjectStore(t *testing.T) {
+	s, err := NewFileObjectStore(t.TempDir())
+	assert.Nil(t, err)
+
+	var msgs []*msg.MsgData
+	for i := 1; i <= 10; i++ {
+		msgs = append(msgs, &msg.MsgData{Msid: uint64(i), Key: "key", Payload: []byte("payload")})
+	}
+	data, err := EncodeRange(msgs)
+	assert.Nil(t, err)
+
+	name := "testTopic/p1/1-10"
+	assert.Nil(t, s.Put(name, data))
+	got, err := s.Get(name)
+	assert.Nil(t, err)
+	decoded, err := DecodeRange(got)
+	assert.Nil(t, err)
+	assert.Equal(t, msgs, decoded)
+
+	assert.Nil(t, s.Delete(name))
+	assert.Nil(t, s.Delete(name))
+	_, err = s.Get(name)
+	assert.NotNil(t, err)
+}
+
+func TestCursorStore(t *testing.T) {
+	defer func() { config.StConf.Type, config.StConf.Dir = "", "" }()
+	config.StConf.Dir = t.TempDir()
+	for _, typ := range []string{StoreMemory, StoreSegment} {
+		config.StConf.Type = typ
+		s, err := NewCursorStore()
+		assert.Nil(t, err)
+
+		_, err = s.Get("/t/p1/s1")
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+		assert.Nil(t, s.Put("/t/p1/s1", []byte("{}")))
+		got, err := s.Get("/t/p1/s1")
+		assert.Nil(t, err)
+		assert.Equal(t, "{}", string(got))
+	}
+
+	// etcd is only there after PersistInit
+	config.StConf.Type = StoreEtcd
+	_, err := NewCursorStore()
+	assert.NotNil(t, err)
+}
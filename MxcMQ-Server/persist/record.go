@@ -0,0 +1,245 @@
+package persist
+
+import (
+	"MxcMQ-Server/codec"
+	"MxcMQ-Server/msg"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sort"
+)
+
+// A message is stored as a binary record:
+//
+//	magic(2) | version(1) | flags(1) | crc(4) | msid(8) | mid(8) | publishTime(8) |
+//	key id(4) | eventTime(8) | deliverAt(8) | expireAt(8) | sequenceId(8) |
+//	key len(2) | key | producer len(2) | producer |
+//	property count(2) | { key len(2) | key | value len(4) | value }... |
+//	payload crc(4) | payload len(4) | payload
+//
+// crc covers everything after itself, payload crc is MsgData.Crc given at
+// publish time. The low bits of flags hold the compression type of the
+// payload, recordHasCrc is set if the payload crc was computed. key id names
+// the keyring key the payload is encrypted with, the payload is then
+// nonce | AES-GCM ciphertext, 0 means plain text. Fields added later are
+// appended behind a flag of their own instead of a new version.
+// Version 1 records hold msid, mid, publish time, properties and payload
+// only. Records which do not start with recordMagic are legacy json encoded
+// MsgData.
+const (
+	recordMagic    uint16 = 0x4d51 // "MQ"
+	recordVersion1 byte   = 1
+	recordVersion2 byte   = 2
+
+	recordCompressionMask byte = 0x07
+	recordHasCrc          byte = 0x08
+
+	recordFixedSize = 2 + 1 + 1 + 4 + 8 + 8 + 8 + 4 + 8 + 8 + 8 + 8 + 2 + 2 + 2 + 4 + 4
+	recordKeyIDPos  = 2 + 1 + 1 + 4 + 8 + 8 + 8
+	maxPropertyKey  = 1<<16 - 1
+	maxProperties   = 1<<16 - 1
+	maxMsgKey       = 1<<16 - 1
+	maxProducerName = 1<<16 - 1
+)
+
+var errShortRecord = errors.New("short record")
+
+// legacyMsgData is MsgData as it was stored in json, with a text payload.
+type legacyMsgData struct {
+	msg.MsgData
+	Payload string
+}
+
+func encodeMsg(m *msg.MsgData) ([]byte, error) {
+	if !m.Compression.Valid() {
+		return nil, fmt.Errorf("unknown compression type: %v", m.Compression)
+	}
+	if len(m.Key) > maxMsgKey {
+		return nil, fmt.Errorf("message key too long: %v", len(m.Key))
+	}
+	if len(m.ProducerName) > maxProducerName {
+		return nil, fmt.Errorf("producer name too long: %v", len(m.ProducerName))
+	}
+	if len(m.Properties) > maxProperties {
+		return nil, fmt.Errorf("too many properties: %v", len(m.Properties))
+	}
+	keys := make([]string, 0, len(m.Properties))
+	size := recordFixedSize + len(m.Key) + len(m.ProducerName) + len(m.Payload)
+	for k, v := range m.Properties {
+		if len(k) > maxPropertyKey {
+			return nil, fmt.Errorf("property key too long: %v", len(k))
+		}
+		keys = append(keys, k)
+		size += 2 + len(k) + 4 + len(v)
+	}
+	sort.Strings(keys)
+
+	payload := m.Payload
+	if keyring != nil {
+		sealed, err := keyring.seal(m.Msid, payload)
+		if err != nil {
+			return nil, err
+		}
+		payload = sealed
+		size += len(sealed) - len(m.Payload)
+	}
+
+	b := make([]byte, 0, size)
+	b = binary.BigEndian.AppendUint16(b, recordMagic)
+	flags := byte(m.Compression) & recordCompressionMask
+	if m.HasCrc {
+		flags |= recordHasCrc
+	}
+	b = append(b, recordVersion2, flags)
+	b = binary.BigEndian.AppendUint32(b, 0)
+	b = binary.BigEndian.AppendUint64(b, m.Msid)
+	b = binary.BigEndian.AppendUint64(b, uint64(m.Mid))
+	b = binary.BigEndian.AppendUint64(b, uint64(m.PublishTime))
+	b = binary.BigEndian.AppendUint32(b, activeKey())
+	b = binary.BigEndian.AppendUint64(b, uint64(m.EventTime))
+	b = binary.BigEndian.AppendUint64(b, uint64(m.DeliverAt))
+	b = binary.BigEndian.AppendUint64(b, uint64(m.ExpireAt))
+	b = binary.BigEndian.AppendUint64(b, uint64(m.SequenceId))
+	b = binary.BigEndian.AppendUint16(b, uint16(len(m.Key)))
+	b = append(b, m.Key...)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(m.ProducerName)))
+	b = append(b, m.ProducerName...)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(keys)))
+	for _, k := range keys {
+		v := m.Properties[k]
+		b = binary.BigEndian.AppendUint16(b, uint16(len(k)))
+		b = append(b, k...)
+		b = binary.BigEndian.AppendUint32(b, uint32(len(v)))
+		b = append(b, v...)
+	}
+	b = binary.BigEndian.AppendUint32(b, m.Crc)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(payload)))
+	b = append(b, payload...)
+
+	binary.BigEndian.PutUint32(b[4:], crc32.Checksum(b[8:], crcTable))
+	return b, nil
+}
+
+func decodeMsg(b []byte) (*msg.MsgData, error) {
+	if len(b) < 2 || binary.BigEndian.Uint16(b) != recordMagic {
+		l := &legacyMsgData{}
+		if err := json.Unmarshal(b, l); err != nil {
+			return nil, err
+		}
+		m := l.MsgData
+		m.Payload = []byte(l.Payload)
+		return &m, nil
+	}
+
+	if len(b) < 8 {
+		return nil, errShortRecord
+	}
+	version := b[2]
+	if version != recordVersion1 && version != recordVersion2 {
+		return nil, fmt.Errorf("unknown record version: %v", version)
+	}
+	if crc32.Checksum(b[8:], crcTable) != binary.BigEndian.Uint32(b[4:]) {
+		return nil, ErrCorruptRecord
+	}
+
+	d := recordDecoder{b: b[8:]}
+	m := &msg.MsgData{
+		Msid:        d.uint64(),
+		Mid:         int64(d.uint64()),
+		PublishTime: int64(d.uint64()),
+		Compression: codec.CompressionType(b[3] & recordCompressionMask),
+	}
+	var keyID uint32
+	if version == recordVersion2 {
+		keyID = d.uint32()
+		m.EventTime = int64(d.uint64())
+		m.DeliverAt = int64(d.uint64())
+		m.ExpireAt = int64(d.uint64())
+		m.SequenceId = int64(d.uint64())
+		m.Key = string(d.bytes(int(d.uint16())))
+		m.ProducerName = string(d.bytes(int(d.uint16())))
+	}
+	if n := int(d.uint16()); n > 0 {
+		m.Properties = make(map[string]string, n)
+		for i := 0; i < n; i++ {
+			k := d.bytes(int(d.uint16()))
+			m.Properties[string(k)] = string(d.bytes(int(d.uint32())))
+		}
+	}
+	if version == recordVersion2 {
+		m.Crc = d.uint32()
+		m.HasCrc = b[3]&recordHasCrc != 0
+	}
+	payload := d.bytes(int(d.uint32()))
+	if d.err != nil {
+		return nil, d.err
+	}
+	if keyID != 0 {
+		if keyring == nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnknownKey, keyID)
+		}
+		var err error
+		if payload, err = keyring.open(keyID, m.Msid, payload); err != nil {
+			return nil, err
+		}
+	}
+	m.Payload = payload
+	return m, nil
+}
+
+// stale tells if the record b is not in the current format or not encrypted
+// with the active key, Rewrite re-encodes such records.
+func stale(b []byte) bool {
+	if len(b) < recordKeyIDPos+4 || binary.BigEndian.Uint16(b) != recordMagic || b[2] != recordVersion2 {
+		return true
+	}
+	return binary.BigEndian.Uint32(b[recordKeyIDPos:]) != activeKey()
+}
+
+// recode decodes and encodes b again with the current format and key.
+func recode(b []byte) ([]byte, error) {
+	m, err := decodeMsg(b)
+	if err != nil {
+		return nil, err
+	}
+	return encodeMsg(m)
+}
+
+// recordDecoder reads big endian fields, the first overrun is kept in err.
+type recordDecoder struct {
+	b   []byte
+	err error
+}
+
+func (d *recordDecoder) bytes(n int) []byte {
+	if d.err != nil || n > len(d.b) {
+		d.err = errShortRecord
+		return nil
+	}
+	v := d.b[:n]
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *recordDecoder) uint16() uint16 {
+	if v := d.bytes(2); v != nil {
+		return binary.BigEndian.Uint16(v)
+	}
+	return 0
+}
+
+func (d *recordDecoder) uint32() uint32 {
+	if v := d.bytes(4); v != nil {
+		return binary.BigEndian.Uint32(v)
+	}
+	return 0
+}
+
+func (d *recordDecoder) uint64() uint64 {
+	if v := d.bytes(8); v != nil {
+		return binary.BigEndian.Uint64(v)
+	}
+	return 0
+}
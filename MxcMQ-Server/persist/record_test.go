@@ -0,0 +1,99 @@
+package persist
+
+import (
+	"MxcMQ-Server/codec"
+	"MxcMQ-Server/msg"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordEncodeDecode(t *testing.T) {
+	m := &msg.MsgData{
+		Msid:         7,
+		Mid:          -3,
+		Key:          "key",
+		Payload:      []byte("payload\x00\xff"),
+		PublishTime:  1700000000000,
+		EventTime:    1699999999000,
+		DeliverAt:    1700000060000,
+		ExpireAt:     1700000120000,
+		SequenceId:   42,
+		ProducerName: "producer",
+		Properties:   map[string]string{"k1": "v1", "k2": ""},
+		Compression:  codec.Compression_Zstd,
+		Crc:          msg.Checksum([]byte("payload\x00\xff")),
+		HasCrc:       true,
+	}
+	b, err := encodeMsg(m)
+	assert.Nil(t, err)
+
+	got, err := decodeMsg(b)
+	assert.Nil(t, err)
+	assert.Equal(t, m, got)
+
+	b[len(b)-1]++
+	_, err = decodeMsg(b)
+	assert.Equal(t, ErrCorruptRecord, err)
+
+	b, _ = encodeMsg(&msg.MsgData{Msid: 1})
+	_, err = decodeMsg(b[:len(b)-2])
+	assert.NotNil(t, err)
+}
+
+func TestRecordCrcFlag(t *testing.T) {
+	m := &msg.MsgData{Msid: 1, Payload: []byte("p"), HasCrc: true}
+	b, err := encodeMsg(m)
+	assert.Nil(t, err)
+	got, err := decodeMsg(b)
+	assert.Nil(t, err)
+	assert.True(t, got.HasCrc)
+	assert.Equal(t, uint32(0), got.Crc)
+
+	m.HasCrc = false
+	b, _ = encodeMsg(m)
+	got, _ = decodeMsg(b)
+	assert.False(t, got.HasCrc)
+}
+
+func TestRecordUnknownVersion(t *testing.T) {
+	b, err := encodeMsg(&msg.MsgData{Msid: 1, Payload: []byte("p")})
+	assert.Nil(t, err)
+	b[2] = recordVersion2 + 1
+	binary.BigEndian.PutUint32(b[4:], crc32.Checksum(b[8:], crcTable))
+	_, err = decodeMsg(b)
+	assert.NotNil(t, err)
+	assert.True(t, stale(b))
+}
+
+func TestRecordDecodeLegacy(t *testing.T) {
+	m := &msg.MsgData{Msid: 3, Mid: 4, Payload: []byte("legacy")}
+	b, err := json.Marshal(struct {
+		Msid    uint64
+		Mid     int64
+		Payload string
+	}{m.Msid, m.Mid, string(m.Payload)})
+	assert.Nil(t, err)
+
+	got, err := decodeMsg(b)
+	assert.Nil(t, err)
+	assert.Equal(t, m, got)
+
+	// version 1 has no key, no producer and no crc
+	b = binary.BigEndian.AppendUint16(nil, recordMagic)
+	b = append(b, recordVersion1, 0, 0, 0, 0, 0)
+	b = binary.BigEndian.AppendUint64(b, m.Msid)
+	b = binary.BigEndian.AppendUint64(b, uint64(m.Mid))
+	b = binary.BigEndian.AppendUint64(b, 0)
+	b = binary.BigEndian.AppendUint16(b, 0)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(m.Payload)))
+	b = append(b, m.Payload...)
+	binary.BigEndian.PutUint32(b[4:], crc32.Checksum(b[8:], crcTable))
+
+	got, err = decodeMsg(b)
+	assert.Nil(t, err)
+	assert.Equal(t, m, got)
+}
@@ -0,0 +1,850 @@
+package persist
+
+import (
+	"MxcMQ-Server/logger"
+	"MxcMQ-Server/msg"
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	logSuffix       = ".log"
+	indexSuffix     = ".index"
+	cleanedSuffix   = ".cleaned"
+	startOffsetFile = "start.offset"
+	segmentNameFmt  = "%020d"
+
+	recordHeaderSize = 16 // body len(4) | crc(4) | msid(8)
+	indexEntrySize   = 8  // relative msid(4) | position(4)
+
+	defaultSegmentBytes  = 64 << 20
+	defaultIndexInterval = 4 << 10
+	maxSegmentBytes      = 1<<32 - 1
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrCorruptRecord is returned when a stored record fails its checksum.
+var ErrCorruptRecord = errors.New("corrupt record")
+
+// segmentStore writes every partition into its own commit log: a directory of
+// append-only segment files, each one with a sparse msid -> position index.
+type segmentStore struct {
+	mu            sync.Mutex
+	dir           string
+	segmentBytes  int64
+	indexInterval int64
+	logs          map[string]*commitLog
+}
+
+type commitLog struct {
+	mu            sync.RWMutex
+	dir           string
+	segmentBytes  int64
+	indexInterval int64
+	segments      []*segment
+	start         uint64 // first readable msid, everything below is deleted
+	last          uint64
+}
+
+type segment struct {
+	base       uint64
+	last       uint64
+	size       int64
+	log        *os.File
+	index      *os.File
+	entries    []indexEntry
+	sinceIndex int64
+}
+
+type indexEntry struct {
+	rel uint32
+	pos uint32
+}
+
+func NewSegmentStore(dir string, segmentBytes int64, indexInterval int64) (MessageStore, error) {
+	if segmentBytes <= 0 {
+		segmentBytes = defaultSegmentBytes
+	}
+	if segmentBytes > maxSegmentBytes {
+		return nil, fmt.Errorf("segmentBytes %v is larger than %v", segmentBytes, int64(maxSegmentBytes))
+	}
+	if indexInterval <= 0 {
+		indexInterval = defaultIndexInterval
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &segmentStore{
+		dir:           dir,
+		segmentBytes:  segmentBytes,
+		indexInterval: indexInterval,
+		logs:          make(map[string]*commitLog),
+	}, nil
+}
+
+func (s *segmentStore) commitLog(topic string, partition int) (*commitLog, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := fmt.Sprintf(partitionKey, topic, partition)
+	if l, ok := s.logs[name]; ok {
+		return l, nil
+	}
+	l, err := openCommitLog(filepath.Join(s.dir, name), s.segmentBytes, s.indexInterval)
+	if err != nil {
+		return nil, err
+	}
+	s.logs[name] = l
+	return l, nil
+}
+
+func (s *segmentStore) Append(topic string, partition int, msgs ...*msg.MsgData) error {
+	l, err := s.commitLog(topic, partition)
+	if err != nil {
+		return err
+	}
+	return l.append(msgs)
+}
+
+func (s *segmentStore) Read(topic string, partition int, start, end uint64) ([]*msg.MsgData, error) {
+	l, err := s.commitLog(topic, partition)
+	if err != nil {
+		return nil, err
+	}
+	return l.read(start, end)
+}
+
+// Delete drops whole segments when [start, end] begins at the head of the
+// commit log, and rewrites the segments holding the range otherwise.
+func (s *segmentStore) Delete(topic string, partition int, start, end uint64) error {
+	l, err := s.commitLog(topic, partition)
+	if err != nil {
+		return err
+	}
+	return l.delete(start, end)
+}
+
+func (s *segmentStore) Remove(topic string, partition int, msids ...uint64) error {
+	l, err := s.commitLog(topic, partition)
+	if err != nil {
+		return err
+	}
+	return l.remove(msids)
+}
+
+func (s *segmentStore) Truncate(topic string, partition int, from uint64) error {
+	l, err := s.commitLog(topic, partition)
+	if err != nil {
+		return err
+	}
+	return l.truncate(from)
+}
+
+func (s *segmentStore) Rewrite(topic string, partition int) (int, error) {
+	l, err := s.commitLog(topic, partition)
+	if err != nil {
+		return 0, err
+	}
+	return l.rewriteStale()
+}
+
+func (s *segmentStore) LastOffset(topic string, partition int) (uint64, error) {
+	l, err := s.commitLog(topic, partition)
+	if err != nil {
+		return 0, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.last, nil
+}
+
+func (s *segmentStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	for name, l := range s.logs {
+		if cerr := l.close(); cerr != nil {
+			err = cerr
+		}
+		delete(s.logs, name)
+	}
+	return err
+}
+
+func openCommitLog(dir string, segmentBytes int64, indexInterval int64) (*commitLog, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	l := &commitLog{
+		dir:           dir,
+		segmentBytes:  segmentBytes,
+		indexInterval: indexInterval,
+	}
+
+	start, err := l.readStartOffset()
+	if err != nil {
+		return nil, err
+	}
+	l.start = start
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var bases []uint64
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), logSuffix) {
+			continue
+		}
+		base, err := strconv.ParseUint(strings.TrimSuffix(f.Name(), logSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		bases = append(bases, base)
+	}
+	sort.Slice(bases, func(i, j int) bool { return bases[i] < bases[j] })
+
+	for i, base := range bases {
+		// the active segment may have a torn tail, always rebuild its index
+		sg, err := openSegment(dir, base, indexInterval, i == len(bases)-1)
+		if err != nil {
+			l.close()
+			return nil, err
+		}
+		l.segments = append(l.segments, sg)
+	}
+
+	if len(l.segments) == 0 {
+		base := l.start
+		if base == 0 {
+			base = 1
+		}
+		sg, err := openSegment(dir, base, indexInterval, true)
+		if err != nil {
+			return nil, err
+		}
+		l.segments = append(l.segments, sg)
+	}
+
+	if l.start < l.segments[0].base {
+		l.start = l.segments[0].base
+	}
+	active := l.active()
+	l.last = active.base - 1
+	for _, sg := range l.segments {
+		if sg.last > l.last {
+			l.last = sg.last
+		}
+	}
+	return l, nil
+}
+
+func (l *commitLog) active() *segment {
+	return l.segments[len(l.segments)-1]
+}
+
+func (l *commitLog) append(msgs []*msg.MsgData) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var buf []byte
+	var msids []uint64
+	var positions []int64
+	active := l.active()
+	last := l.last
+	for _, m := range msgs {
+		if m.Msid <= last {
+			return fmt.Errorf("msid %v is not after last msid %v", m.Msid, last)
+		}
+		rec, err := encodeRecord(m)
+		if err != nil {
+			return err
+		}
+
+		pos := active.size + int64(len(buf))
+		if pos > 0 && pos+int64(len(rec)) > l.segmentBytes {
+			if err := active.write(buf, msids, positions, l.indexInterval); err != nil {
+				return err
+			}
+			l.last = last
+			if err := l.roll(m.Msid); err != nil {
+				return err
+			}
+			active = l.active()
+			buf, msids, positions = buf[:0], msids[:0], positions[:0]
+			pos = 0
+		}
+		buf = append(buf, rec...)
+		msids = append(msids, m.Msid)
+		positions = append(positions, pos)
+		last = m.Msid
+	}
+
+	if err := active.write(buf, msids, positions, l.indexInterval); err != nil {
+		return err
+	}
+	if err := active.log.Sync(); err != nil {
+		return err
+	}
+	l.last = last
+	return nil
+}
+
+func (l *commitLog) roll(base uint64) error {
+	sealed := l.active()
+	if err := sealed.log.Sync(); err != nil {
+		return err
+	}
+	if err := sealed.index.Sync(); err != nil {
+		return err
+	}
+
+	sg, err := openSegment(l.dir, base, l.indexInterval, true)
+	if err != nil {
+		return err
+	}
+	l.segments = append(l.segments, sg)
+	return nil
+}
+
+func (l *commitLog) read(start, end uint64) ([]*msg.MsgData, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var msgs []*msg.MsgData
+	if start < l.start {
+		start = l.start
+	}
+	if end > l.last {
+		end = l.last
+	}
+	if start > end {
+		return msgs, nil
+	}
+
+	i := sort.Search(len(l.segments), func(i int) bool { return l.segments[i].base > start }) - 1
+	if i < 0 {
+		i = 0
+	}
+	for ; i < len(l.segments); i++ {
+		sg := l.segments[i]
+		if sg.base > end {
+			break
+		}
+		if err := sg.read(start, end, func(m *msg.MsgData) { msgs = append(msgs, m) }); err != nil {
+			return nil, err
+		}
+	}
+	return msgs, nil
+}
+
+func (l *commitLog) delete(start, end uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if end < l.start {
+		return nil
+	}
+	if end > l.last {
+		end = l.last
+	}
+	if start > end {
+		return nil
+	}
+	if start > l.start {
+		return l.rewriteSegments(
+			func(base, last uint64) bool { return base <= end && last >= start },
+			func(msid uint64) bool { return msid >= start && msid <= end })
+	}
+	l.start = end + 1
+	if err := l.writeStartOffset(); err != nil {
+		return err
+	}
+
+	// the active segment is kept so that the last msid survives a restart
+	for len(l.segments) > 1 && l.segments[1].base <= l.start {
+		sg := l.segments[0]
+		l.segments = l.segments[1:]
+		if err := sg.remove(); err != nil {
+			logger.Warnf("remove segment %v failed: %v", sg.log.Name(), err)
+		}
+	}
+	return nil
+}
+
+// remove rewrites every segment holding one of msids without them.
+func (l *commitLog) remove(msids []uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	drop := make(map[uint64]bool, len(msids))
+	for _, msid := range msids {
+		drop[msid] = true
+	}
+	return l.rewriteSegments(func(base, last uint64) bool {
+		for msid := range drop {
+			if msid >= base && msid <= last {
+				return true
+			}
+		}
+		return false
+	}, func(msid uint64) bool { return drop[msid] })
+}
+
+// rewriteSegments rewrites the segments from base to last which hit tells,
+// without the messages drop tells. l.mu is held.
+func (l *commitLog) rewriteSegments(hit func(base, last uint64) bool, drop func(msid uint64) bool) error {
+	for i, sg := range l.segments {
+		last := l.last
+		if i+1 < len(l.segments) {
+			last = l.segments[i+1].base - 1
+		}
+		if !hit(sg.base, last) {
+			continue
+		}
+
+		cleaned, err := sg.rewrite(l.dir, l.indexInterval, func(msid uint64, body []byte) ([]byte, error) {
+			if drop(msid) {
+				return nil, nil
+			}
+			return body, nil
+		})
+		if err != nil {
+			return err
+		}
+		l.segments[i] = cleaned
+	}
+	return nil
+}
+
+// truncate drops the tail of the log from msid from on.
+func (l *commitLog) truncate(from uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if from > l.last {
+		return nil
+	}
+	if from < l.start {
+		return fmt.Errorf("commit log %v can not be truncated under its head %v", l.dir, l.start)
+	}
+	// the first segment stays to be the active one
+	for len(l.segments) > 1 && l.active().base >= from {
+		sg := l.active()
+		l.segments = l.segments[:len(l.segments)-1]
+		if err := sg.remove(); err != nil {
+			return err
+		}
+	}
+	cleaned, err := l.active().rewrite(l.dir, l.indexInterval, func(msid uint64, body []byte) ([]byte, error) {
+		if msid >= from {
+			return nil, nil
+		}
+		return body, nil
+	})
+	if err != nil {
+		return err
+	}
+	// unlike remove, the msids of the dropped tail are free again
+	cleaned.last = from - 1
+	l.segments[len(l.segments)-1] = cleaned
+	l.last = from - 1
+	return nil
+}
+
+// rewriteStale re-encodes the stale records of every segment which has some.
+func (l *commitLog) rewriteStale() (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	total := 0
+	for i, sg := range l.segments {
+		n := 0
+		if err := sg.scan(func(msid uint64, body []byte) {
+			if stale(body) {
+				n++
+			}
+		}); err != nil {
+			return total, err
+		}
+		if n == 0 {
+			continue
+		}
+
+		recoded, err := sg.rewrite(l.dir, l.indexInterval, func(msid uint64, body []byte) ([]byte, error) {
+			if !stale(body) {
+				return body, nil
+			}
+			return recode(body)
+		})
+		if err != nil {
+			return total, err
+		}
+		l.segments[i] = recoded
+		total += n
+	}
+	return total, nil
+}
+
+func (l *commitLog) readStartOffset() (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(l.dir, startOffsetFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if len(data) != 8 {
+		return 0, nil
+	}
+	return binary.BigEndian.Uint64(data), nil
+}
+
+func (l *commitLog) writeStartOffset() error {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, l.start)
+	path := filepath.Join(l.dir, startOffsetFile)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (l *commitLog) close() error {
+	var err error
+	for _, sg := range l.segments {
+		if cerr := sg.close(); cerr != nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+func openSegment(dir string, base uint64, indexInterval int64, recover bool) (*segment, error) {
+	name := filepath.Join(dir, fmt.Sprintf(segmentNameFmt, base))
+	logFile, err := os.OpenFile(name+logSuffix, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	indexFile, err := os.OpenFile(name+indexSuffix, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		logFile.Close()
+		return nil, err
+	}
+	sg := &segment{
+		base:  base,
+		log:   logFile,
+		index: indexFile,
+	}
+
+	info, err := logFile.Stat()
+	if err != nil {
+		sg.close()
+		return nil, err
+	}
+	sg.size = info.Size()
+
+	if !recover {
+		if err := sg.loadIndex(); err != nil {
+			logger.Warnf("index of segment %v is broken, rebuild it: %v", name, err)
+			recover = true
+		}
+	}
+	if err := sg.recover(indexInterval, recover); err != nil {
+		sg.close()
+		return nil, err
+	}
+	return sg, nil
+}
+
+func (sg *segment) loadIndex() error {
+	data, err := io.ReadAll(io.NewSectionReader(sg.index, 0, 1<<62))
+	if err != nil {
+		return err
+	}
+	if len(data)%indexEntrySize != 0 {
+		return errors.New("index size is not aligned")
+	}
+
+	sg.entries = sg.entries[:0]
+	for i := 0; i < len(data); i += indexEntrySize {
+		e := indexEntry{
+			rel: binary.BigEndian.Uint32(data[i:]),
+			pos: binary.BigEndian.Uint32(data[i+4:]),
+		}
+		if int64(e.pos) >= sg.size {
+			return errors.New("index entry is out of segment")
+		}
+		if n := len(sg.entries); n > 0 && (e.rel <= sg.entries[n-1].rel || e.pos <= sg.entries[n-1].pos) {
+			return errors.New("index entries are not ascending")
+		}
+		sg.entries = append(sg.entries, e)
+	}
+	return nil
+}
+
+// recover scans the segment, from its last index entry or, when rebuilding,
+// from the beginning, and truncates anything after the last valid record.
+func (sg *segment) recover(indexInterval int64, rebuild bool) error {
+	var pos int64
+	if rebuild {
+		sg.entries = sg.entries[:0]
+	} else if n := len(sg.entries); n > 0 {
+		pos = int64(sg.entries[n-1].pos)
+	}
+
+	r := bufio.NewReader(io.NewSectionReader(sg.log, pos, sg.size-pos))
+	sg.sinceIndex = 0
+	for pos < sg.size {
+		msid, n, _, err := readRecord(r, sg.size-pos)
+		if err != nil {
+			if err != ErrCorruptRecord && err != io.ErrUnexpectedEOF {
+				return err
+			}
+			logger.Warnf("truncate segment %v at %v: %v", sg.log.Name(), pos, err)
+			if err := sg.log.Truncate(pos); err != nil {
+				return err
+			}
+			sg.size = pos
+			break
+		}
+		if rebuild && (len(sg.entries) == 0 || sg.sinceIndex >= indexInterval) {
+			sg.entries = append(sg.entries, indexEntry{rel: uint32(msid - sg.base), pos: uint32(pos)})
+			sg.sinceIndex = 0
+		}
+		sg.last = msid
+		sg.sinceIndex += n
+		pos += n
+	}
+
+	if rebuild {
+		return sg.writeIndex()
+	}
+	return nil
+}
+
+func (sg *segment) writeIndex() error {
+	data := make([]byte, 0, len(sg.entries)*indexEntrySize)
+	for _, e := range sg.entries {
+		data = binary.BigEndian.AppendUint32(data, e.rel)
+		data = binary.BigEndian.AppendUint32(data, e.pos)
+	}
+	if err := sg.index.Truncate(0); err != nil {
+		return err
+	}
+	_, err := sg.index.WriteAt(data, 0)
+	return err
+}
+
+func (sg *segment) write(buf []byte, msids []uint64, positions []int64, indexInterval int64) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	if _, err := sg.log.WriteAt(buf, sg.size); err != nil {
+		return err
+	}
+
+	var index []byte
+	for i, msid := range msids {
+		if len(sg.entries) == 0 || sg.sinceIndex >= indexInterval {
+			e := indexEntry{rel: uint32(msid - sg.base), pos: uint32(positions[i])}
+			sg.entries = append(sg.entries, e)
+			index = binary.BigEndian.AppendUint32(index, e.rel)
+			index = binary.BigEndian.AppendUint32(index, e.pos)
+			sg.sinceIndex = 0
+		}
+		if i+1 < len(positions) {
+			sg.sinceIndex += positions[i+1] - positions[i]
+		} else {
+			sg.sinceIndex += sg.size + int64(len(buf)) - positions[i]
+		}
+	}
+	if len(index) > 0 {
+		if _, err := sg.index.WriteAt(index, int64(len(sg.entries)-len(index)/indexEntrySize)*indexEntrySize); err != nil {
+			return err
+		}
+	}
+
+	sg.size += int64(len(buf))
+	sg.last = msids[len(msids)-1]
+	return nil
+}
+
+func (sg *segment) read(start, end uint64, fn func(m *msg.MsgData)) error {
+	var pos int64
+	if start > sg.base {
+		rel := start - sg.base
+		i := sort.Search(len(sg.entries), func(i int) bool { return uint64(sg.entries[i].rel) > rel }) - 1
+		if i >= 0 {
+			pos = int64(sg.entries[i].pos)
+		}
+	}
+
+	r := bufio.NewReader(io.NewSectionReader(sg.log, pos, sg.size-pos))
+	for pos < sg.size {
+		msid, n, body, err := readRecord(r, sg.size-pos)
+		if err != nil {
+			return fmt.Errorf("read segment %v at %v: %w", sg.log.Name(), pos, err)
+		}
+		pos += n
+		if msid < start {
+			continue
+		}
+		if msid > end {
+			return nil
+		}
+		m, err := decodeMsg(body)
+		if err != nil {
+			return err
+		}
+		fn(m)
+	}
+	return nil
+}
+
+// scan calls fn with every record of sg.
+func (sg *segment) scan(fn func(msid uint64, body []byte)) error {
+	r := bufio.NewReader(io.NewSectionReader(sg.log, 0, sg.size))
+	for pos := int64(0); pos < sg.size; {
+		msid, n, body, err := readRecord(r, sg.size-pos)
+		if err != nil {
+			return fmt.Errorf("read segment %v at %v: %w", sg.log.Name(), pos, err)
+		}
+		pos += n
+		fn(msid, body)
+	}
+	return nil
+}
+
+// rewrite copies the records of sg, as fn returns them, into a new file,
+// swaps it in and returns the reopened segment. A nil body drops the record.
+func (sg *segment) rewrite(dir string, indexInterval int64, fn func(msid uint64, body []byte) ([]byte, error)) (*segment, error) {
+	name := sg.log.Name()
+	tmp, err := os.Create(name + cleanedSuffix)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	r := bufio.NewReader(io.NewSectionReader(sg.log, 0, sg.size))
+	for pos := int64(0); pos < sg.size; {
+		msid, n, body, err := readRecord(r, sg.size-pos)
+		if err != nil {
+			tmp.Close()
+			return nil, fmt.Errorf("read segment %v at %v: %w", name, pos, err)
+		}
+		pos += n
+		if body, err = fn(msid, body); err != nil {
+			tmp.Close()
+			return nil, err
+		}
+		if body == nil {
+			continue
+		}
+		if _, err := w.Write(frameRecord(msid, body)); err != nil {
+			tmp.Close()
+			return nil, err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := sg.close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return nil, err
+	}
+	cleaned, err := openSegment(dir, sg.base, indexInterval, true)
+	if err != nil {
+		return nil, err
+	}
+	// keep the msid of a dropped tail, later appends must stay above it
+	if cleaned.last < sg.last {
+		cleaned.last = sg.last
+	}
+	return cleaned, nil
+}
+
+func (sg *segment) close() error {
+	err := sg.log.Close()
+	if ierr := sg.index.Close(); ierr != nil {
+		err = ierr
+	}
+	return err
+}
+
+func (sg *segment) remove() error {
+	if err := sg.close(); err != nil {
+		return err
+	}
+	if err := os.Remove(sg.log.Name()); err != nil {
+		return err
+	}
+	return os.Remove(sg.index.Name())
+}
+
+func encodeRecord(m *msg.MsgData) ([]byte, error) {
+	body, err := encodeMsg(m)
+	if err != nil {
+		return nil, err
+	}
+	return frameRecord(m.Msid, body), nil
+}
+
+func frameRecord(msid uint64, body []byte) []byte {
+	rec := make([]byte, recordHeaderSize+len(body))
+	binary.BigEndian.PutUint32(rec[0:], uint32(len(body)))
+	binary.BigEndian.PutUint64(rec[8:], msid)
+	copy(rec[recordHeaderSize:], body)
+	binary.BigEndian.PutUint32(rec[4:], crc32.Checksum(rec[8:], crcTable))
+	return rec
+}
+
+// readRecord returns the msid, the whole size and the body of the next record.
+func readRecord(r *bufio.Reader, remain int64) (uint64, int64, []byte, error) {
+	if remain < recordHeaderSize {
+		return 0, 0, nil, io.ErrUnexpectedEOF
+	}
+	header := make([]byte, recordHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, 0, nil, err
+	}
+	n := int64(binary.BigEndian.Uint32(header[0:]))
+	if recordHeaderSize+n > remain {
+		return 0, 0, nil, io.ErrUnexpectedEOF
+	}
+	body := make([]byte, n)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, 0, nil, err
+	}
+
+	crc := crc32.Update(crc32.Checksum(header[8:], crcTable), crcTable, body)
+	if crc != binary.BigEndian.Uint32(header[4:]) {
+		return 0, 0, nil, ErrCorruptRecord
+	}
+	return binary.BigEndian.Uint64(header[8:]), recordHeaderSize + n, body, nil
+}
@@ -0,0 +1,219 @@
+package persist
+
+import (
+	"MxcMQ-Server/msg"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func appendN(t *testing.T, s MessageStore, topic string, partition int, from, to int) {
+	var msgs []*msg.MsgData
+	for i := from; i <= to; i++ {
+		msgs = append(msgs, &msg.MsgData{Msid: uint64(i), Mid: int64(i), Payload: []byte(fmt.Sprintf("payload-%d", i))})
+	}
+	err := s.Append(topic, partition, msgs...)
+	assert.Nil(t, err)
+}
+
+func TestSegmentStoreAppendAndRead(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewSegmentStore(dir, 512, 64)
+	assert.Nil(t, err)
+
+	topic := "testTopic"
+	partition := 1
+	appendN(t, s, topic, partition, 1, 50)
+	appendN(t, s, topic, partition, 51, 100)
+
+	segments, _ := filepath.Glob(filepath.Join(dir, topic, "p1", "*"+logSuffix))
+	assert.Greater(t, len(segments), 1)
+
+	last, err := s.LastOffset(topic, partition)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(100), last)
+
+	data, err := s.Read(topic, partition, 37, 64)
+	assert.Nil(t, err)
+	assert.Equal(t, 28, len(data))
+	for i, m := range data {
+		assert.Equal(t, uint64(37+i), m.Msid)
+		assert.Equal(t, fmt.Sprintf("payload-%d", 37+i), string(m.Payload))
+	}
+
+	err = s.Append(topic, partition, &msg.MsgData{Msid: 100})
+	assert.NotNil(t, err)
+	assert.Nil(t, s.Close())
+}
+
+func TestSegmentStoreRecover(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewSegmentStore(dir, 512, 64)
+	assert.Nil(t, err)
+
+	topic := "testTopic"
+	partition := 1
+	appendN(t, s, topic, partition, 1, 40)
+	assert.Nil(t, s.Close())
+
+	// tear the tail of the active segment and break an index
+	segments, _ := filepath.Glob(filepath.Join(dir, topic, "p1", "*"+logSuffix))
+	active := segments[len(segments)-1]
+	info, err := os.Stat(active)
+	assert.Nil(t, err)
+	assert.Nil(t, os.Truncate(active, info.Size()-3))
+	assert.Nil(t, os.WriteFile(segments[0][:len(segments[0])-len(logSuffix)]+indexSuffix, []byte{1, 2, 3}, 0644))
+
+	s, err = NewSegmentStore(dir, 512, 64)
+	assert.Nil(t, err)
+	last, err := s.LastOffset(topic, partition)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(39), last)
+
+	data, err := s.Read(topic, partition, 1, 40)
+	assert.Nil(t, err)
+	assert.Equal(t, 39, len(data))
+
+	appendN(t, s, topic, partition, 40, 45)
+	data, err = s.Read(topic, partition, 38, 45)
+	assert.Nil(t, err)
+	assert.Equal(t, 8, len(data))
+	assert.Equal(t, "payload-45", string(data[7].Payload))
+	assert.Nil(t, s.Close())
+}
+
+func TestSegmentStoreDelete(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewSegmentStore(dir, 512, 64)
+	assert.Nil(t, err)
+
+	topic := "testTopic"
+	partition := 1
+	appendN(t, s, topic, partition, 1, 100)
+	before, _ := filepath.Glob(filepath.Join(dir, topic, "p1", "*"+logSuffix))
+
+	// in the middle the segments are rewritten
+	err = s.Delete(topic, partition, 70, 80)
+	assert.Nil(t, err)
+	data, err := s.Read(topic, partition, 60, 90)
+	assert.Nil(t, err)
+	assert.Equal(t, 20, len(data))
+	assert.Equal(t, uint64(69), data[9].Msid)
+	assert.Equal(t, uint64(81), data[10].Msid)
+
+	err = s.Delete(topic, partition, 1, 60)
+	assert.Nil(t, err)
+	after, _ := filepath.Glob(filepath.Join(dir, topic, "p1", "*"+logSuffix))
+	assert.Less(t, len(after), len(before))
+
+	data, err = s.Read(topic, partition, 1, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 29, len(data))
+	assert.Equal(t, uint64(61), data[0].Msid)
+	assert.Nil(t, s.Close())
+
+	s, err = NewSegmentStore(dir, 512, 64)
+	assert.Nil(t, err)
+	data, err = s.Read(topic, partition, 1, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 29, len(data))
+	assert.Nil(t, s.Close())
+}
+
+func TestSegmentStoreRemove(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewSegmentStore(dir, 512, 64)
+	assert.Nil(t, err)
+
+	topic := "testTopic"
+	partition := 1
+	appendN(t, s, topic, partition, 1, 30)
+	assert.Nil(t, s.Remove(topic, partition, 2, 3, 17, 30))
+
+	data, err := s.Read(topic, partition, 1, 30)
+	assert.Nil(t, err)
+	assert.Equal(t, 26, len(data))
+	for _, m := range data {
+		assert.NotContains(t, []uint64{2, 3, 17, 30}, m.Msid)
+	}
+
+	// the last msid is still taken by the removed message
+	err = s.Append(topic, partition, &msg.MsgData{Msid: 30})
+	assert.NotNil(t, err)
+	appendN(t, s, topic, partition, 31, 31)
+	assert.Nil(t, s.Close())
+
+	s, err = NewSegmentStore(dir, 512, 64)
+	assert.Nil(t, err)
+	data, err = s.Read(topic, partition, 15, 31)
+	assert.Nil(t, err)
+	assert.Equal(t, 15, len(data))
+	assert.Nil(t, s.Close())
+}
+
+func TestSegmentStoreTruncate(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewSegmentStore(dir, 512, 64)
+	assert.Nil(t, err)
+
+	topic := "testTopic"
+	partition := 1
+	appendN(t, s, topic, partition, 1, 30)
+	// the tail spans several segments
+	assert.Nil(t, s.Truncate(topic, partition, 12))
+	last, err := s.LastOffset(topic, partition)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(11), last)
+
+	// the msids are free again
+	appendN(t, s, topic, partition, 12, 14)
+	assert.Nil(t, s.Close())
+
+	s, err = NewSegmentStore(dir, 512, 64)
+	assert.Nil(t, err)
+	last, err = s.LastOffset(topic, partition)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(14), last)
+	data, err := s.Read(topic, partition, 1, 30)
+	assert.Nil(t, err)
+	assert.Equal(t, 14, len(data))
+	assert.Equal(t, []byte("payload-13"), data[12].Payload)
+	assert.Nil(t, s.Close())
+}
+
+func TestSegmentStoreRewrite(t *testing.T) {
+	defer SetKeyring(nil)
+	dir := t.TempDir()
+	s, err := NewSegmentStore(dir, 512, 64)
+	assert.Nil(t, err)
+
+	topic := "testTopic"
+	partition := 1
+	appendN(t, s, topic, partition, 1, 20)
+	SetKeyring(testKeyring(t, 1, 1))
+	appendN(t, s, topic, partition, 21, 30)
+
+	n, err := s.Rewrite(topic, partition)
+	assert.Nil(t, err)
+	assert.Equal(t, 20, n)
+	n, err = s.Rewrite(topic, partition)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+
+	data, err := s.Read(topic, partition, 1, 30)
+	assert.Nil(t, err)
+	assert.Equal(t, 30, len(data))
+	appendN(t, s, topic, partition, 31, 31)
+	assert.Nil(t, s.Close())
+
+	segments, _ := filepath.Glob(filepath.Join(dir, topic, "p1", "*"+logSuffix))
+	for _, name := range segments {
+		b, err := os.ReadFile(name)
+		assert.Nil(t, err)
+		assert.False(t, bytes.Contains(b, []byte("payload-")))
+	}
+}
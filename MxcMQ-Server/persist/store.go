@@ -0,0 +1,81 @@
+package persist
+
+import (
+	"MxcMQ-Server/config"
+	"MxcMQ-Server/msg"
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+const (
+	StoreEtcd    = "etcd"
+	StoreMemory  = "memory"
+	StoreSegment = "segment"
+
+	// where the segment store keeps the cursors, under its dir
+	cursorDir = ".cursors"
+)
+
+// MessageStore keeps the messages of every topic/partition, addressed by Msid.
+type MessageStore interface {
+	// Append persists msgs in order, each one under its own Msid.
+	Append(topic string, partition int, msgs ...*msg.MsgData) error
+	// Read returns the stored messages whose Msid is in [start, end], in order.
+	Read(topic string, partition int, start, end uint64) ([]*msg.MsgData, error)
+	// Delete removes the messages whose Msid is in [start, end].
+	Delete(topic string, partition int, start, end uint64) error
+	// Remove deletes single messages wherever they are, the others keep their Msid.
+	Remove(topic string, partition int, msids ...uint64) error
+	// Truncate removes the messages from Msid from on, later appends may use
+	// their Msids again.
+	Truncate(topic string, partition int, from uint64) error
+	// Rewrite re-encodes the records of a partition which are in an old format
+	// or under an old key, and returns how many it rewrote.
+	Rewrite(topic string, partition int) (int, error)
+	// LastOffset returns the largest stored Msid, 0 if the partition is empty.
+	LastOffset(topic string, partition int) (uint64, error)
+	Close() error
+}
+
+func NewMessageStore() (MessageStore, error) {
+	if config.StConf.KeyringFile != "" {
+		k, err := LoadKeyring(config.StConf.KeyringFile)
+		if err != nil {
+			return nil, err
+		}
+		SetKeyring(k)
+	}
+
+	switch config.StConf.Type {
+	case StoreEtcd, "":
+		if EtcdCli == nil {
+			return nil, errors.New("etcd store needs PersistInit first")
+		}
+		return NewEtcdStore(EtcdCli), nil
+	case StoreMemory:
+		return NewMemoryStore(), nil
+	case StoreSegment:
+		return NewSegmentStore(config.StConf.Dir, config.StConf.SegmentBytes, config.StConf.IndexIntervalBytes)
+	default:
+		return nil, fmt.Errorf("unknown message store type: %v", config.StConf.Type)
+	}
+}
+
+// NewCursorStore returns where the subscriptions are kept, next to the
+// messages of the configured store.
+func NewCursorStore() (ObjectStore, error) {
+	switch config.StConf.Type {
+	case StoreEtcd, "":
+		if EtcdCli == nil {
+			return nil, errors.New("etcd store needs PersistInit first")
+		}
+		return NewEtcdObjectStore(EtcdCli), nil
+	case StoreMemory:
+		return NewMemoryObjectStore(), nil
+	case StoreSegment:
+		return NewFileObjectStore(filepath.Join(config.StConf.Dir, cursorDir))
+	default:
+		return nil, fmt.Errorf("unknown message store type: %v", config.StConf.Type)
+	}
+}
@@ -51,19 +51,67 @@ type BrokerNode struct {
 }
 
 type TopicNode struct {
-	Name       string
-	Pnum       int
-	PulishMode int
-}
+	Name        string
+	Pnum        int
+	PulishMode  int
+	Compression int // default compression type of the publishers
+	Retention   RetentionPolicy
+	Offload     OffloadPolicy
+	Quota       QuotaPolicy
+	MessageTTL  int64 // second, 0 means no ttl
+	Version     int32
+}
+
+// zero value means no limit
+type RetentionPolicy struct {
+	MaxAge        int64 // second
+	MaxBytes      int64
+	MaxMsgs       uint64
+	DeleteUnacked bool // allow to delete messages which are not acked by all subscriptions
+	DeleteAcked   bool // delete messages as soon as all subscriptions acked them
+	Compact       bool // keep only the latest message of every key
+}
+
+type OffloadPolicy struct {
+	MaxHotMsgs uint64 // older messages are offloaded a whole range at a time, 0 means never
+}
+
+// zero value means no quota, the limits apply to every partition
+type QuotaPolicy struct {
+	MaxBacklogMsgs  uint64 // messages not acked by every subscription
+	MaxStorageBytes int64  // payload bytes retained
+	Action          int    // what a publish over quota does
+}
+
+const (
+	QuotaReject = iota // fail the publish
+	QuotaHold          // wait until there is room
+	QuotaEvict         // delete the oldest messages, acked or not
+)
 
 type PartitionNode struct {
-	ID         int
-	TopicName  string
-	Mnum       uint64
-	AckOffset  uint64
-	PushOffset uint64
-	Url        string
-	Version    int32
+	ID            int
+	TopicName     string
+	Mnum          uint64
+	AckOffset     uint64
+	PushOffset    uint64
+	DeleteOffset  uint64 // messages up to DeleteOffset have been deleted
+	CompactOffset uint64 // messages up to CompactOffset have been compacted
+	OffloadOffset uint64 // messages up to OffloadOffset live in the object store
+	Offloaded     []OffloadRange
+	Size          int64            // payload bytes retained
+	Sequences     map[string]int64 // last sequence id persisted of each producer
+	SequenceTimes map[string]int64 // unix ms of the last persist of each producer
+	Url           string
+	Version       int32
+}
+
+// OffloadRange is one object of the object store, or several of Step
+// messages each when offloads of the same size followed each other.
+type OffloadRange struct {
+	Start uint64
+	End   uint64
+	Step  uint64 `json:",omitempty"`
 }
 
 type BundleNode struct {
@@ -439,6 +487,28 @@ func (c *ZkClient) GetSub(snode *SubcriptionNode) (*SubcriptionNode, error) {
 	return sNode, nil
 }
 
+func (c *ZkClient) GetSubs(topic string, partition int) ([]*SubcriptionNode, error) {
+	var subs []*SubcriptionNode
+	path := fmt.Sprintf("%v/%v/p%v/subscription", c.ZkTopicRoot, topic, partition)
+	zNodes, _, err := c.Conn.Children(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, zNode := range zNodes {
+		data, _, err := c.Conn.Get(path + "/" + zNode)
+		if err != nil {
+			return nil, err
+		}
+		sNode := &SubcriptionNode{}
+		if err = json.Unmarshal(data, sNode); err != nil {
+			return nil, err
+		}
+		subs = append(subs, sNode)
+	}
+	return subs, nil
+}
+
 func (c *ZkClient) GetPartition(topic string, partition int) (*PartitionNode, error) {
 	path := fmt.Sprintf(PnodePath, c.ZkTopicRoot, topic, partition)
 	logger.Debugf("GetPartition from %v", path)
@@ -715,6 +785,19 @@ func (c *ZkClient) UpdatePartition(pNode *PartitionNode) error {
 	return err
 }
 
+func (c *ZkClient) UpdateTopic(tNode *TopicNode) error {
+	path := fmt.Sprintf(TnodePath, c.ZkTopicRoot, tNode.Name)
+	version := tNode.Version
+	tNode.Version++
+	data, err := json.Marshal(tNode)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Conn.Set(path, data, version)
+	return err
+}
+
 func (c *ZkClient) UpdateBroker(bNode *BrokerNode) error {
 	// logger.Debugf("UpdateBroker: %v", bNode)
 	path := fmt.Sprintf(BnodePath, c.ZkBrokerRoot, bNode.Name)
@@ -774,6 +857,24 @@ func (c *ZkClient) DeleteLeadPuber(topic string, partition int) error {
 	return nil
 }
 
+// DeleteTopic removes the node of topic and every node under it.
+func (c *ZkClient) DeleteTopic(topic string) error {
+	return c.deleteTree(fmt.Sprintf(TnodePath, c.ZkTopicRoot, topic))
+}
+
+func (c *ZkClient) deleteTree(path string) error {
+	children, _, err := c.Conn.Children(path)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if err := c.deleteTree(path + "/" + child); err != nil {
+			return err
+		}
+	}
+	return c.Conn.Delete(path, -1)
+}
+
 func (c *ZkClient) Close() {
 	c.Conn.Close()
 }
@@ -0,0 +1,124 @@
+package server
+
+import "sort"
+
+// AckRange is a run of individually acked msids, From and To included.
+type AckRange struct {
+	From uint64
+	To   uint64
+}
+
+// ack marks msid as acked. The mark delete position AckOffset moves on once
+// every msid up to it is acked. It tells if msid was not acked yet.
+func (sub *subcription) ack(msid uint64) bool {
+	if sub.isAcked(msid) {
+		return false
+	}
+	delete(sub.inflight, msid)
+	prev := sub.Data.AckOffset
+	acked := sub.Data.Acked
+	i := sort.Search(len(acked), func(i int) bool { return acked[i].To >= msid })
+	switch {
+	case i > 0 && acked[i-1].To+1 == msid && i < len(acked) && acked[i].From == msid+1:
+		acked[i-1].To = acked[i].To
+		acked = append(acked[:i], acked[i+1:]...)
+	case i > 0 && acked[i-1].To+1 == msid:
+		acked[i-1].To = msid
+	case i < len(acked) && acked[i].From == msid+1:
+		acked[i].From = msid
+	default:
+		acked = append(acked, AckRange{})
+		copy(acked[i+1:], acked[i:])
+		acked[i] = AckRange{From: msid, To: msid}
+	}
+	sub.Data.Acked = acked
+	sub.markDelete(prev)
+	return true
+}
+
+// ackCumulative acks every msid up to offset but the delayed ones, which the
+// consumer did not get yet. It tells if anything was not acked yet.
+func (sub *subcription) ackCumulative(offset uint64) bool {
+	if offset <= sub.Data.AckOffset {
+		return false
+	}
+	var delayed []uint64
+	for _, d := range sub.delayed {
+		if d.msid > sub.Data.AckOffset && d.msid <= offset {
+			delayed = append(delayed, d.msid)
+		}
+	}
+	sort.Slice(delayed, func(i, j int) bool { return delayed[i] < delayed[j] })
+
+	from := sub.Data.AckOffset + 1
+	for _, msid := range delayed {
+		if msid > from {
+			sub.ackRange(from, msid-1)
+		}
+		from = msid + 1
+	}
+	if from <= offset {
+		sub.ackRange(from, offset)
+	}
+	return true
+}
+
+// ackRange acks every msid of [from, to].
+func (sub *subcription) ackRange(from, to uint64) {
+	if from <= sub.Data.AckOffset+1 {
+		prev := sub.Data.AckOffset
+		if to > sub.Data.AckOffset {
+			sub.Data.AckOffset = to
+		}
+		sub.markDelete(prev)
+		return
+	}
+	acked := sub.Data.Acked
+	i := sort.Search(len(acked), func(i int) bool { return acked[i].To+1 >= from })
+	j := i
+	for ; j < len(acked) && acked[j].From <= to+1; j++ {
+		if acked[j].From < from {
+			from = acked[j].From
+		}
+		if acked[j].To > to {
+			to = acked[j].To
+		}
+	}
+	merged := append(append(acked[:i:i], AckRange{From: from, To: to}), acked[j:]...)
+	sub.Data.Acked = merged
+}
+
+// markDelete moves AckOffset over the acked ranges it reaches and drops the
+// ranges under it, prev is where AckOffset was before the ack.
+func (sub *subcription) markDelete(prev uint64) {
+	acked := sub.Data.Acked
+	for len(acked) > 0 && acked[0].From <= sub.Data.AckOffset+1 {
+		if acked[0].To > sub.Data.AckOffset {
+			sub.Data.AckOffset = acked[0].To
+		}
+		acked = acked[1:]
+	}
+	if len(acked) == 0 {
+		acked = nil
+	}
+	sub.Data.Acked = acked
+	sub.forgetAcked(prev+1, sub.Data.AckOffset)
+}
+
+func (sub *subcription) isAcked(msid uint64) bool {
+	if msid <= sub.Data.AckOffset {
+		return true
+	}
+	acked := sub.Data.Acked
+	i := sort.Search(len(acked), func(i int) bool { return acked[i].To >= msid })
+	return i < len(acked) && acked[i].From <= msid
+}
+
+// rewind moves the cursor of sub back to the mark delete position, so the
+// messages pushed but not acked before the broker stopped are delivered again.
+// The acked ones are skipped by the cursor.
+func (sub *subcription) rewind() {
+	if sub.Data.PushOffset > sub.Data.AckOffset+1 {
+		sub.Data.PushOffset = sub.Data.AckOffset + 1
+	}
+}
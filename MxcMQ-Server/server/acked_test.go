@@ -0,0 +1,172 @@
+package server
+
+import (
+	pb "MxcMQ-Server/proto"
+	rc "MxcMQ-Server/registrationCenter"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestAck(t *testing.T) {
+	sub := NewSubcription()
+	sub.Data.AckOffset = 2
+	sub.Data.PushOffset = 10
+
+	assert.True(t, sub.ack(5))
+	assert.True(t, sub.ack(7))
+	assert.True(t, sub.ack(6))
+	assert.False(t, sub.ack(6))
+	assert.True(t, sub.ack(9))
+	assert.Equal(t, []AckRange{{5, 7}, {9, 9}}, sub.Data.Acked)
+	assert.Equal(t, uint64(2), sub.Data.AckOffset)
+	assert.True(t, sub.isAcked(6))
+	assert.False(t, sub.isAcked(8))
+
+	// the mark delete position catches up with the acked ranges
+	assert.True(t, sub.ack(3))
+	assert.True(t, sub.ack(4))
+	assert.Equal(t, uint64(7), sub.Data.AckOffset)
+	assert.Equal(t, []AckRange{{9, 9}}, sub.Data.Acked)
+
+	assert.False(t, sub.ackCumulative(5))
+	assert.True(t, sub.ackCumulative(8))
+	assert.Equal(t, uint64(9), sub.Data.AckOffset)
+	assert.Nil(t, sub.Data.Acked)
+	assert.False(t, sub.ack(1))
+
+	// ranges acked at once merge with their neighbours
+	sub.Data.Acked = []AckRange{{12, 12}, {15, 16}, {20, 20}}
+	sub.ackRange(13, 14)
+	assert.Equal(t, []AckRange{{12, 16}, {20, 20}}, sub.Data.Acked)
+	sub.ackRange(10, 11)
+	assert.Equal(t, uint64(16), sub.Data.AckOffset)
+	assert.Equal(t, []AckRange{{20, 20}}, sub.Data.Acked)
+}
+
+func TestAckForgets(t *testing.T) {
+	sub := NewSubcription()
+	sub.Data.AckTimeout = 1000
+	for msid := uint64(1); msid <= 10; msid++ {
+		sub.track(msid, "c1", 0)
+		sub.failed(msid, "timeout")
+	}
+
+	// an ack out of order is not delivered again, its count stays until the
+	// mark delete position passes it
+	sub.ack(3)
+	assert.NotContains(t, sub.inflight, uint64(3))
+	assert.Equal(t, 1, sub.redeliveryCount(3))
+	sub.ack(1)
+	sub.ack(2)
+	assert.Equal(t, 0, sub.redeliveryCount(3))
+	assert.Equal(t, 1, sub.redeliveryCount(4))
+	assert.Contains(t, sub.inflight, uint64(4))
+
+	sub.ackCumulative(8)
+	assert.Len(t, sub.inflight, 2)
+	assert.Len(t, sub.deliveries, 2)
+
+	// wider than what is tracked
+	sub.ackCumulative(1 << 40)
+	assert.Empty(t, sub.inflight)
+	assert.Empty(t, sub.deliveries)
+
+	// acked before it failed or was pushed, nothing is tracked
+	sub.failed(5, "timeout")
+	sub.track(5, "c1", 0)
+	assert.Empty(t, sub.inflight)
+	assert.Empty(t, sub.deliveries)
+}
+
+func TestRewind(t *testing.T) {
+	sub := NewSubcription()
+	sub.Data.AckOffset = 2
+	sub.Data.PushOffset = 8
+	sub.ack(4)
+	sub.ack(6)
+
+	data, err := json.Marshal(sub.Data)
+	assert.Nil(t, err)
+	restored := NewSubcription()
+	assert.Nil(t, json.Unmarshal(data, restored.Data))
+	restored.rewind()
+	assert.Equal(t, uint64(3), restored.Data.PushOffset)
+
+	var unacked []uint64
+	for i := restored.Data.PushOffset; i < 8; i++ {
+		if !restored.isAcked(i) {
+			unacked = append(unacked, i)
+		}
+	}
+	assert.Equal(t, []uint64{3, 5, 7}, unacked)
+}
+
+func TestMsgAck(t *testing.T) {
+	s, p := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1})
+	p.reaperOnce.Do(func() {})
+	p.pNode.Mnum = 10
+	exclusive := addSub(t, s, "t", "s1", int(SMode_Exclusive))
+	shared := addSub(t, s, "t", "s2", int(SMode_Shard))
+	keyShared := addSub(t, s, "t", "s3", int(SMode_KeyShard))
+	ack := func(sub *subcription, ackOffset uint64, msids ...uint64) error {
+		_, err := s.MsgAck(context.Background(), &pb.MsgAckArgs{
+			Topic:        "t",
+			Partition:    1,
+			Subscription: sub.Data.Meta.Name,
+			AckOffset:    ackOffset,
+			Msids:        msids,
+		})
+		return err
+	}
+
+	assert.Nil(t, ack(exclusive, 4))
+	assert.Equal(t, uint64(4), exclusive.Data.AckOffset)
+
+	// a cumulative ack is refused as a whole
+	for _, sub := range []*subcription{shared, keyShared} {
+		assert.NotNil(t, ack(sub, 4, 6))
+		assert.Equal(t, uint64(0), sub.Data.AckOffset)
+		assert.False(t, sub.isAcked(6))
+
+		assert.Nil(t, ack(sub, 0, 1, 2, 6))
+		assert.Equal(t, uint64(2), sub.Data.AckOffset)
+		assert.True(t, sub.isAcked(6))
+	}
+}
+
+func TestResubscribe(t *testing.T) {
+	s, p := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1})
+	p.reaperOnce.Do(func() {})
+	p.pNode.Mnum = 10
+	p.pNode.PushOffset = 10
+	sub := addSub(t, s, "t", "s1", int(SMode_Shard))
+	addConsumer(s, sub, "c1")
+	sub.Data.PushOffset = 8
+	sub.ack(1)
+	sub.ack(2)
+	sub.ack(5)
+
+	// the consumer comes back on a new connection, the cursor and the acks
+	// of the subscription stay where they are
+	s.conns.Store("c1", &grpc.ClientConn{})
+	_, err := s.ProcessSub(context.Background(), &pb.SubscribeArgs{
+		Name:         "c1",
+		Topic:        "t",
+		Partition:    1,
+		Subscription: "s1",
+		Mode:         pb.SubscribeArgs_SubMode(SMode_Shard),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(8), sub.Data.PushOffset)
+	assert.Equal(t, uint64(2), sub.Data.AckOffset)
+
+	stored, err := s.GetSubcription(&sub.Data.Meta)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(8), stored.Data.PushOffset)
+	assert.Equal(t, uint64(2), stored.Data.AckOffset)
+	assert.True(t, stored.isAcked(5))
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"MxcMQ-Server/msg"
+	"container/heap"
+	"time"
+)
+
+const ackTimeoutReason = "ack timeout"
+
+// inflightMsg is a message delivered to consumer and not acked yet.
+type inflightMsg struct {
+	consumer string
+	deadline int64 // unix millisecond, 0 without ack timeout
+}
+
+// track records msid as delivered to consumer and starts its ack timeout.
+func (sub *subcription) track(msid uint64, consumer string, now int64) {
+	if sub.isAcked(msid) {
+		return
+	}
+	in := inflightMsg{consumer: consumer}
+	if sub.Data.AckTimeout > 0 {
+		in.deadline = now + sub.Data.AckTimeout
+		heap.Push(&sub.ackDeadlines, delayedMsg{at: in.deadline, msid: msid})
+	}
+	sub.inflight[msid] = in
+}
+
+// ackTimedOut queues the messages not acked before their deadline for
+// redelivery. The consumer which let one time out is passed over for it for
+// one more ack timeout if others are there. It returns the messages to be dead
+// lettered with their delivery counts.
+func (sub *subcription) ackTimedOut(now int64) map[uint64]int {
+	var dead map[uint64]int
+	for len(sub.ackDeadlines) > 0 && sub.ackDeadlines[0].at <= now {
+		d := heap.Pop(&sub.ackDeadlines).(delayedMsg)
+		in, ok := sub.inflight[d.msid]
+		if !ok || in.deadline != d.at {
+			// acked, nacked or delivered again since
+			continue
+		}
+		delete(sub.inflight, d.msid)
+		count, isDead := sub.failed(d.msid, ackTimeoutReason)
+		sub.deliveries[d.msid].consumer = in.consumer
+		sub.deliveries[d.msid].avoidUntil = now + sub.Data.AckTimeout
+		if isDead {
+			if dead == nil {
+				dead = make(map[uint64]int)
+			}
+			dead[d.msid] = count
+			continue
+		}
+		sub.redeliver(d.msid)
+	}
+	return dead
+}
+
+// nextRedeliveryFor is nextRedelivery for the consumer name.
+func (sub *subcription) nextRedeliveryFor(name string, now int64) (uint64, bool) {
+	if len(sub.Data.Subers) < 2 {
+		return sub.nextRedelivery()
+	}
+	for i, msid := range sub.redeliveries {
+		if d, ok := sub.deliveries[msid]; ok && d.consumer == name && now < d.avoidUntil {
+			continue
+		}
+		sub.redeliveries = append(sub.redeliveries[:i], sub.redeliveries[i+1:]...)
+		return msid, true
+	}
+	return 0, false
+}
+
+// redeliverTimedOut handles the messages of sub whose ack timeout is over.
+func (s *Server) redeliverTimedOut(pua *msg.PullArg, sub *subcription) {
+	sub.mu.Lock()
+	dead := sub.ackTimedOut(time.Now().UnixMilli())
+	sub.mu.Unlock()
+	s.deadLetterMsids(pua, sub, dead, ackTimeoutReason)
+}
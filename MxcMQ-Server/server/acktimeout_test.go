@@ -0,0 +1,38 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAckTimeout(t *testing.T) {
+	sub := NewSubcription()
+	sub.Data.AckTimeout = 100
+	sub.Data.DeadLetter.MaxDeliveries = 2
+	sub.Data.Subers = map[string]string{"c1": "c1", "c2": "c2"}
+
+	sub.track(1, "c1", 0)
+	sub.track(2, "c1", 0)
+	sub.track(3, "c2", 50)
+	sub.ack(2)
+	assert.Nil(t, sub.ackTimedOut(99))
+	assert.Nil(t, sub.ackTimedOut(100))
+	assert.Equal(t, 1, sub.redeliveryCount(1))
+	assert.Equal(t, 0, sub.redeliveryCount(2))
+
+	// c1 let it time out, c2 gets it
+	_, ok := sub.nextRedeliveryFor("c1", 120)
+	assert.False(t, ok)
+	msid, ok := sub.nextRedeliveryFor("c2", 120)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), msid)
+
+	// delivered again, the old deadline does not count
+	sub.track(1, "c2", 120)
+	sub.track(3, "c2", 120)
+	assert.Nil(t, sub.ackTimedOut(150))
+	assert.Equal(t, map[uint64]int{1: 2}, sub.ackTimedOut(220))
+	msid, _ = sub.nextRedeliveryFor("c1", 220)
+	assert.Equal(t, uint64(3), msid)
+}
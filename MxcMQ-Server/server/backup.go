@@ -0,0 +1,357 @@
+package server
+
+import (
+	"MxcMQ-Server/logger"
+	"MxcMQ-Server/msg"
+	"MxcMQ-Server/persist"
+	rc "MxcMQ-Server/registrationCenter"
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// A topic archive is a header followed by entries:
+//
+//	magic(4) | version(1)
+//	kind(1) | crc(4) | len(4) | data
+//
+// The topic entry comes first, then every partition entry is followed by the
+// subscriptions and the message ranges of that partition. Metadata entries
+// are json, message ranges are persist.EncodeRange blobs. The archive ends
+// with an empty archiveEnd entry so truncated files are rejected.
+const (
+	archiveMagic   uint32 = 0x4d514152 // "MQAR"
+	archiveVersion byte   = 1
+
+	archiveTopic        byte = 1
+	archivePartition    byte = 2
+	archiveSubscription byte = 3
+	archiveMsgs         byte = 4
+	archiveEnd          byte = 5
+
+	// number of messages read from the store per archiveMsgs entry
+	archiveBatchMsgs = 1000
+)
+
+var (
+	errBadArchive     = errors.New("not a topic archive")
+	errCorruptArchive = errors.New("corrupt archive entry")
+	archiveCrcTable   = crc32.MakeTable(crc32.Castagnoli)
+)
+
+type archiveWriter struct {
+	w *bufio.Writer
+}
+
+func newArchiveWriter(w io.Writer) (*archiveWriter, error) {
+	aw := &archiveWriter{w: bufio.NewWriter(w)}
+	var hdr [5]byte
+	binary.BigEndian.PutUint32(hdr[:], archiveMagic)
+	hdr[4] = archiveVersion
+	if _, err := aw.w.Write(hdr[:]); err != nil {
+		return nil, err
+	}
+	return aw, nil
+}
+
+func (aw *archiveWriter) write(kind byte, data []byte) error {
+	var hdr [9]byte
+	hdr[0] = kind
+	binary.BigEndian.PutUint32(hdr[1:], crc32.Checksum(data, archiveCrcTable))
+	binary.BigEndian.PutUint32(hdr[5:], uint32(len(data)))
+	if _, err := aw.w.Write(hdr[:]); err != nil {
+		return err
+	}
+	_, err := aw.w.Write(data)
+	return err
+}
+
+func (aw *archiveWriter) writeJSON(kind byte, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return aw.write(kind, data)
+}
+
+func (aw *archiveWriter) close() error {
+	if err := aw.write(archiveEnd, nil); err != nil {
+		return err
+	}
+	return aw.w.Flush()
+}
+
+type archiveReader struct {
+	r *bufio.Reader
+}
+
+func newArchiveReader(r io.Reader) (*archiveReader, error) {
+	ar := &archiveReader{r: bufio.NewReader(r)}
+	var hdr [5]byte
+	if _, err := io.ReadFull(ar.r, hdr[:]); err != nil {
+		return nil, errBadArchive
+	}
+	if binary.BigEndian.Uint32(hdr[:]) != archiveMagic {
+		return nil, errBadArchive
+	}
+	if hdr[4] != archiveVersion {
+		return nil, fmt.Errorf("unknown archive version: %v", hdr[4])
+	}
+	return ar, nil
+}
+
+func (ar *archiveReader) next() (byte, []byte, error) {
+	var hdr [9]byte
+	if _, err := io.ReadFull(ar.r, hdr[:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, nil, err
+	}
+	data := make([]byte, binary.BigEndian.Uint32(hdr[5:]))
+	if _, err := io.ReadFull(ar.r, data); err != nil {
+		return 0, nil, err
+	}
+	if crc32.Checksum(data, archiveCrcTable) != binary.BigEndian.Uint32(hdr[1:]) {
+		return 0, nil, errCorruptArchive
+	}
+	return hdr[0], data, nil
+}
+
+// ExportTopic writes the topic, its partitions, subscription cursors and
+// retained messages to w. Offloaded messages are read back from the object store.
+func (s *Server) ExportTopic(topic string, w io.Writer) error {
+	tNode, err := s.meta.GetTopic(topic)
+	if err != nil {
+		return err
+	}
+	aw, err := newArchiveWriter(w)
+	if err != nil {
+		return err
+	}
+	if err := aw.writeJSON(archiveTopic, tNode); err != nil {
+		return err
+	}
+
+	for i := 1; i <= tNode.Pnum; i++ {
+		if err := s.exportPartition(aw, topic, i); err != nil {
+			return fmt.Errorf("export %v/%v: %w", topic, i, err)
+		}
+	}
+	return aw.close()
+}
+
+func (s *Server) exportPartition(aw *archiveWriter, topic string, partition int) error {
+	pNode, err := s.meta.GetPartition(topic, partition)
+	if err != nil {
+		return err
+	}
+	// the store may be ahead of zk if the broker crashed before UpdatePartition
+	last, err := s.store.LastOffset(topic, partition)
+	if err != nil {
+		return err
+	}
+	if last > pNode.Mnum {
+		pNode.Mnum = last
+	}
+	if err := aw.writeJSON(archivePartition, pNode); err != nil {
+		return err
+	}
+
+	sNodes, err := s.meta.GetSubs(topic, partition)
+	if err != nil {
+		return err
+	}
+	for _, sNode := range sNodes {
+		sub, err := s.GetSubcription(sNode)
+		if err != nil {
+			return err
+		}
+		if err := aw.writeJSON(archiveSubscription, sub.Data); err != nil {
+			return err
+		}
+	}
+
+	// the partition is not loaded, cold only keeps the last offloaded object
+	// read for the next batches
+	cold := &partitionData{}
+	for start := pNode.DeleteOffset + 1; start <= pNode.Mnum; start += archiveBatchMsgs {
+		end := start + archiveBatchMsgs - 1
+		if end > pNode.Mnum {
+			end = pNode.Mnum
+		}
+		var msgs []*msg.MsgData
+		if s.objects == nil {
+			msgs, err = s.store.Read(topic, partition, start, end)
+		} else {
+			msgs, err = s.readRange(cold, topic, partition, pNode.Offloaded, pNode.OffloadOffset, start, end)
+		}
+		if err != nil {
+			return err
+		}
+		if len(msgs) == 0 {
+			continue
+		}
+		data, err := persist.EncodeRange(msgs)
+		if err != nil {
+			return err
+		}
+		if err := aw.write(archiveMsgs, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importedPartition is a partition of an archive being imported.
+type importedPartition struct {
+	pNode *rc.PartitionNode
+	subs  []*subcription
+	last  uint64 // last msid appended to the store, 0 if none
+}
+
+// ImportTopic recreates the topic archived in r with its messages in the
+// store in use and returns its name. The topic must not exist yet. Nothing is
+// registered before the whole archive is read, and a failed import removes
+// what it created.
+func (s *Server) ImportTopic(r io.Reader) (string, error) {
+	ar, err := newArchiveReader(r)
+	if err != nil {
+		return "", err
+	}
+	kind, data, err := ar.next()
+	if err != nil {
+		return "", err
+	}
+	if kind != archiveTopic {
+		return "", errBadArchive
+	}
+	tNode := &rc.TopicNode{}
+	if err := json.Unmarshal(data, tNode); err != nil {
+		return "", err
+	}
+	isExists, err := s.meta.IsTopicExists(tNode.Name)
+	if err != nil {
+		return "", err
+	}
+	if isExists {
+		return "", fmt.Errorf("topic %v already exists", tNode.Name)
+	}
+	tNode.Version = 0
+
+	var parts []*importedPartition
+	if err := s.readArchive(ar, tNode, &parts); err != nil {
+		s.dropImported(tNode.Name, parts, false)
+		return tNode.Name, err
+	}
+	if err := s.registerImported(tNode, parts); err != nil {
+		s.dropImported(tNode.Name, parts, true)
+		return tNode.Name, err
+	}
+	logger.Infof("import topic %v over", tNode.Name)
+	return tNode.Name, nil
+}
+
+// readArchive appends the messages of ar to the store and adds the
+// partitions it holds to parts.
+func (s *Server) readArchive(ar *archiveReader, tNode *rc.TopicNode, parts *[]*importedPartition) error {
+	var part *importedPartition
+	for {
+		kind, data, err := ar.next()
+		if err != nil {
+			return err
+		}
+		switch kind {
+		case archivePartition:
+			pNode := &rc.PartitionNode{}
+			if err := json.Unmarshal(data, pNode); err != nil {
+				return err
+			}
+			// every message comes back hot, the offloader moves them out again
+			pNode.TopicName = tNode.Name
+			pNode.OffloadOffset = 0
+			pNode.Offloaded = nil
+			pNode.Url = ""
+			pNode.Version = 0
+			part = &importedPartition{pNode: pNode}
+			*parts = append(*parts, part)
+		case archiveSubscription:
+			if part == nil {
+				return errBadArchive
+			}
+			sub := NewSubcription()
+			if err := json.Unmarshal(data, sub.Data); err != nil {
+				return err
+			}
+			// subers are connections to the old cluster
+			sub.Data.Subers = make(map[string]string)
+			sub.Data.Meta.TopicName = tNode.Name
+			sub.Data.Meta.Partition = part.pNode.ID
+			part.subs = append(part.subs, sub)
+		case archiveMsgs:
+			if part == nil {
+				return errBadArchive
+			}
+			msgs, err := persist.DecodeRange(data)
+			if err != nil {
+				return err
+			}
+			if len(msgs) == 0 {
+				continue
+			}
+			if err := s.store.Append(tNode.Name, part.pNode.ID, msgs...); err != nil {
+				return err
+			}
+			part.last = msgs[len(msgs)-1].Msid
+		case archiveEnd:
+			return nil
+		default:
+			return fmt.Errorf("unknown archive entry: %v", kind)
+		}
+	}
+}
+
+// registerImported creates the nodes of an archive read by readArchive, the
+// topic node goes first as zk wants parents before children.
+func (s *Server) registerImported(tNode *rc.TopicNode, parts []*importedPartition) error {
+	if err := s.registerTopic(tNode); err != nil {
+		return err
+	}
+	for _, part := range parts {
+		if err := s.meta.RegisterPnode(part.pNode); err != nil {
+			return err
+		}
+		for _, sub := range part.subs {
+			if err := s.meta.RegisterSnode(&sub.Data.Meta); err != nil {
+				return err
+			}
+			if err := s.PutSubcription(sub); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dropImported removes the messages of a failed import from the store, and
+// its nodes if registered. Subscriptions left in etcd have no node pointing
+// at them and are overwritten by the next import.
+func (s *Server) dropImported(topic string, parts []*importedPartition, registered bool) {
+	if registered {
+		if err := s.meta.DeleteTopic(topic); err != nil {
+			logger.Errorf("delete imported topic %v failed: %v", topic, err)
+		}
+	}
+	for _, part := range parts {
+		if part.last == 0 {
+			continue
+		}
+		if err := s.store.Delete(topic, part.pNode.ID, 1, part.last); err != nil {
+			logger.Errorf("delete imported messages of %v/p%v failed: %v", topic, part.pNode.ID, err)
+		}
+	}
+}
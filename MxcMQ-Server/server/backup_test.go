@@ -0,0 +1,142 @@
+package server
+
+import (
+	"MxcMQ-Server/config"
+	"MxcMQ-Server/msg"
+	"MxcMQ-Server/persist"
+	rc "MxcMQ-Server/registrationCenter"
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchive(t *testing.T) {
+	var buf bytes.Buffer
+	aw, err := newArchiveWriter(&buf)
+	assert.Nil(t, err)
+	assert.Nil(t, aw.writeJSON(archiveTopic, map[string]int{"Pnum": 1}))
+	assert.Nil(t, aw.write(archiveMsgs, []byte("msgs")))
+	assert.Nil(t, aw.close())
+	data := buf.Bytes()
+
+	ar, err := newArchiveReader(bytes.NewReader(data))
+	assert.Nil(t, err)
+	kind, v, err := ar.next()
+	assert.Nil(t, err)
+	assert.Equal(t, archiveTopic, kind)
+	assert.Equal(t, `{"Pnum":1}`, string(v))
+	kind, v, err = ar.next()
+	assert.Nil(t, err)
+	assert.Equal(t, archiveMsgs, kind)
+	assert.Equal(t, "msgs", string(v))
+	kind, _, err = ar.next()
+	assert.Nil(t, err)
+	assert.Equal(t, archiveEnd, kind)
+
+	// truncated
+	ar, _ = newArchiveReader(bytes.NewReader(data[:len(data)-9]))
+	ar.next()
+	ar.next()
+	_, _, err = ar.next()
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+	// corrupt
+	corrupt := append([]byte(nil), data...)
+	corrupt[len(corrupt)-10]++
+	ar, _ = newArchiveReader(bytes.NewReader(corrupt))
+	ar.next()
+	_, _, err = ar.next()
+	assert.Equal(t, errCorruptArchive, err)
+
+	_, err = newArchiveReader(bytes.NewReader([]byte("json")))
+	assert.Equal(t, errBadArchive, err)
+}
+
+func TestExportImport(t *testing.T) {
+	src, p := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1})
+	for _, payload := range []string{"a", "b", "c"} {
+		assert.Nil(t, src.commitMsgs(p, &msg.MsgData{Key: payload, Payload: []byte(payload)}))
+	}
+	sub := addSub(t, src, "t", "s1", Shared)
+	sub.Data.AckOffset = 2
+	assert.Nil(t, src.PutSubcription(sub))
+
+	var buf bytes.Buffer
+	assert.Nil(t, src.ExportTopic("t", &buf))
+	data := buf.Bytes()
+
+	newServer := func() *Server {
+		return &Server{store: persist.NewMemoryStore(), meta: newMemMeta(), cursors: persist.NewMemoryObjectStore()}
+	}
+	dst := newServer()
+	name, err := dst.ImportTopic(bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.Equal(t, "t", name)
+	pNode, err := dst.meta.GetPartition("t", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), pNode.Mnum)
+	msgs, err := dst.store.Read("t", 1, 1, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, msids(msgs))
+	assert.Equal(t, []byte("c"), msgs[2].Payload)
+	got, err := dst.GetSubcription(&rc.SubcriptionNode{Name: "s1", TopicName: "t", Partition: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), got.Data.AckOffset)
+	assert.Equal(t, Shared, got.Data.Meta.Subtype)
+
+	// once is enough
+	_, err = dst.ImportTopic(bytes.NewReader(data))
+	assert.NotNil(t, err)
+
+	// a truncated archive leaves nothing behind
+	dst = newServer()
+	_, err = dst.ImportTopic(bytes.NewReader(data[:len(data)-9]))
+	assert.NotNil(t, err)
+	isExists, _ := dst.meta.IsTopicExists("t")
+	assert.False(t, isExists)
+	msgs, _ = dst.store.Read("t", 1, 1, 3)
+	assert.Empty(t, msgs)
+
+	// neither does a failed registration
+	dst = newServer()
+	assert.Nil(t, dst.meta.RegisterSnode(&rc.SubcriptionNode{Name: "s1", TopicName: "t", Partition: 1}))
+	_, err = dst.ImportTopic(bytes.NewReader(data))
+	assert.NotNil(t, err)
+	isExists, _ = dst.meta.IsTopicExists("t")
+	assert.False(t, isExists)
+	isExists, _ = dst.meta.IsPartitionExists("t", 1)
+	assert.False(t, isExists)
+	msgs, _ = dst.store.Read("t", 1, 1, 3)
+	assert.Empty(t, msgs)
+}
+
+func TestExportOffloaded(t *testing.T) {
+	config.StConf.OffloadRangeMsgs = 3
+	defer func() { config.StConf.OffloadRangeMsgs = 0 }()
+
+	src, p := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1, Offload: rc.OffloadPolicy{MaxHotMsgs: 2}})
+	objects, err := persist.NewFileObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	src.objects = objects
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, src.commitMsgs(p, &msg.MsgData{Payload: []byte("m")}))
+	}
+	assert.Nil(t, src.offload(p))
+
+	// exported as a command does it, without the partition loaded
+	src.partitions.Delete(fmt.Sprintf(partitionKey, "t", 1))
+	var buf bytes.Buffer
+	assert.Nil(t, src.ExportTopic("t", &buf))
+	_, loaded := src.partitions.Load(fmt.Sprintf(partitionKey, "t", 1))
+	assert.False(t, loaded)
+
+	dst := &Server{store: persist.NewMemoryStore(), meta: newMemMeta(), cursors: persist.NewMemoryObjectStore()}
+	_, err = dst.ImportTopic(&buf)
+	assert.Nil(t, err)
+	msgs, err := dst.store.Read("t", 1, 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, msids(msgs))
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"MxcMQ-Server/codec"
+	"MxcMQ-Server/msg"
+	pb "MxcMQ-Server/proto"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// unbatch returns the messages of a batched publish. Each one is compressed
+// on its own with the codec of the batch, so subscribers get it as if it was
+// published alone.
+func unbatch(args *pb.PublishArgs, publishTime int64) ([]*msg.MsgData, error) {
+	c := codec.CompressionType(args.Compression)
+	data, err := codec.Decompress(c, args.Payload, maxMessageSize())
+	if err != nil {
+		return nil, err
+	}
+	batch := &pb.Batch{}
+	if err := proto.Unmarshal(data, batch); err != nil {
+		return nil, err
+	}
+	n := len(batch.Entries)
+	if n == 0 || n != int(args.BatchSize) {
+		return nil, fmt.Errorf("batch of %v messages holds %v", args.BatchSize, n)
+	}
+
+	msgs := make([]*msg.MsgData, 0, n)
+	for i, e := range batch.Entries {
+		payload, err := codec.Compress(c, e.Payload)
+		if err != nil {
+			return nil, err
+		}
+		m := &msg.MsgData{
+			Mid:          args.Mid,
+			Key:          e.Key,
+			Payload:      payload,
+			PublishTime:  publishTime,
+			EventTime:    e.EventTime,
+			DeliverAt:    e.DeliverAt,
+			ProducerName: args.ProducerName,
+			Properties:   e.Properties,
+			Compression:  c,
+			Crc:          msg.Checksum(payload),
+			HasCrc:       true,
+		}
+		if args.SequenceId > 0 {
+			m.SequenceId = args.SequenceId - int64(n-1-i)
+		}
+		if e.Ttl > 0 {
+			m.ExpireAt = publishTime + e.Ttl
+		}
+		msgs = append(msgs, m)
+	}
+	return msgs, nil
+}
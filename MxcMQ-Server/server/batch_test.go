@@ -0,0 +1,101 @@
+package server
+
+import (
+	"MxcMQ-Server/codec"
+	"MxcMQ-Server/config"
+	"MxcMQ-Server/msg"
+	"MxcMQ-Server/persist"
+	pb "MxcMQ-Server/proto"
+	rc "MxcMQ-Server/registrationCenter"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPublishBatch(t *testing.T) {
+	config.SrvConf.SyncWrite2disk = true
+	config.SrvConf.BrokerDeduplicationEnabled = true
+	defer func() {
+		config.SrvConf.SyncWrite2disk = false
+		config.SrvConf.BrokerDeduplicationEnabled = false
+	}()
+
+	meta := newMemMeta()
+	assert.Nil(t, meta.RegisterTnode(&rc.TopicNode{Name: "t", Pnum: 1}))
+	pNode := &rc.PartitionNode{TopicName: "t", ID: 1}
+	assert.Nil(t, meta.RegisterPnode(pNode))
+	s := &Server{store: persist.NewMemoryStore(), meta: meta, Sl: NewSublist(), cache: newMsgCache(1 << 20)}
+	s.partitions.Store(fmt.Sprintf(partitionKey, "t", 1), &partitionData{pNode: pNode})
+
+	// packed the way the client packs it
+	batch := &pb.Batch{}
+	for i := 0; i < 3; i++ {
+		batch.Entries = append(batch.Entries, &pb.BatchEntry{
+			Key:        fmt.Sprintf("k%v", i),
+			Payload:    []byte(fmt.Sprintf(`{"doc":%v,"body":"%0200d"}`, i, i)),
+			Properties: map[string]string{"i": fmt.Sprint(i)},
+		})
+	}
+	data, err := proto.Marshal(batch)
+	assert.Nil(t, err)
+	payload, err := codec.Compress(codec.Compression_Zstd, data)
+	assert.Nil(t, err)
+	assert.Less(t, len(payload), len(data))
+	args := &pb.PublishArgs{
+		Topic:        "t",
+		Partition:    1,
+		Payload:      payload,
+		Compression:  int32(codec.Compression_Zstd),
+		Crc:          msg.Checksum(payload),
+		ProducerName: "p1",
+		SequenceId:   3,
+		BatchSize:    3,
+	}
+
+	reply, err := s.ProcessPub(context.Background(), args)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), reply.Msid)
+
+	msgs, err := s.store.Read("t", 1, 1, 3)
+	assert.Nil(t, err)
+	assert.Len(t, msgs, 3)
+	for i, m := range msgs {
+		assert.True(t, m.HasCrc)
+		assert.Nil(t, m.Verify())
+		assert.Equal(t, codec.Compression_Zstd, m.Compression)
+		got, err := codec.Decompress(m.Compression, m.Payload, maxMessageSize())
+		assert.Nil(t, err)
+		assert.Equal(t, batch.Entries[i].Payload, got)
+		assert.Equal(t, batch.Entries[i].Key, m.Key)
+		assert.Equal(t, batch.Entries[i].Properties, m.Properties)
+		assert.Equal(t, int64(i+1), m.SequenceId)
+	}
+
+	// a retry of the whole batch is dropped
+	reply, err = s.ProcessPub(context.Background(), args)
+	assert.Nil(t, err)
+	assert.True(t, reply.Duplicate)
+	assert.Equal(t, uint64(3), pNode.Mnum)
+
+	args.BatchSize = 4
+	_, err = s.ProcessPub(context.Background(), args)
+	assert.NotNil(t, err)
+}
+
+func TestUnbatchTooLarge(t *testing.T) {
+	config.SrvConf.MaxMessageSize = 4 << 10
+	defer func() { config.SrvConf.MaxMessageSize = 0 }()
+
+	// a few bytes on the wire, far more once decompressed
+	data, err := proto.Marshal(&pb.Batch{Entries: []*pb.BatchEntry{{Payload: make([]byte, 1<<20)}}})
+	assert.Nil(t, err)
+	payload, err := codec.Compress(codec.Compression_Gzip, data)
+	assert.Nil(t, err)
+	assert.Less(t, len(payload), 4<<10)
+
+	_, err = unbatch(&pb.PublishArgs{Payload: payload, Compression: int32(codec.Compression_Gzip), BatchSize: 1}, 0)
+	assert.ErrorIs(t, err, codec.ErrTooLarge)
+}
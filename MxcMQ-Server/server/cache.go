@@ -0,0 +1,143 @@
+package server
+
+import (
+	"MxcMQ-Server/msg"
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// bytes counted for a cached message besides its topic and payload
+const cacheEntryOverhead = 64
+
+// msgCache is a LRU of messages shared by all partitions of the broker,
+// indexed by partition and msid and bounded by the bytes it holds.
+type msgCache struct {
+	mu       sync.Mutex
+	capacity int64
+	size     int64
+	count    int
+	lru      *list.List // front is the most recently used
+	items    map[cachePartition]map[uint64]*list.Element
+
+	hits   uint64
+	misses uint64
+}
+
+type cachePartition struct {
+	topic     string
+	partition int
+}
+
+type cacheEntry struct {
+	part cachePartition
+	m    *msg.MsgData
+	size int64
+}
+
+// newMsgCache returns a cache holding up to capacity bytes, nothing is cached
+// if capacity is not positive.
+func newMsgCache(capacity int64) *msgCache {
+	return &msgCache{
+		capacity: capacity,
+		lru:      list.New(),
+		items:    make(map[cachePartition]map[uint64]*list.Element),
+	}
+}
+
+func cacheEntrySize(topic string, m *msg.MsgData) int64 {
+	return int64(len(topic)+len(m.Key)+len(m.Payload)) + cacheEntryOverhead
+}
+
+func (c *msgCache) get(topic string, partition int, msid uint64) (*msg.MsgData, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[cachePartition{topic, partition}][msid]
+	if !ok {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	atomic.AddUint64(&c.hits, 1)
+	c.lru.MoveToFront(e)
+	return e.Value.(*cacheEntry).m, true
+}
+
+func (c *msgCache) put(topic string, partition int, m *msg.MsgData) {
+	size := cacheEntrySize(topic, m)
+	if size > c.capacity {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	part := cachePartition{topic, partition}
+	items, ok := c.items[part]
+	if !ok {
+		items = make(map[uint64]*list.Element)
+		c.items[part] = items
+	}
+	if e, ok := items[m.Msid]; ok {
+		c.removeElement(e)
+	}
+	items[m.Msid] = c.lru.PushFront(&cacheEntry{part: part, m: m, size: size})
+	c.size += size
+	c.count++
+	for c.size > c.capacity {
+		c.removeElement(c.lru.Back())
+	}
+}
+
+func (c *msgCache) remove(topic string, partition int, msids ...uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	items := c.items[cachePartition{topic, partition}]
+	for _, msid := range msids {
+		if e, ok := items[msid]; ok {
+			c.removeElement(e)
+		}
+	}
+}
+
+// removeRange evicts the messages first to last of a partition, it walks the
+// range or the cached messages of the partition, whichever is shorter.
+func (c *msgCache) removeRange(topic string, partition int, first, last uint64) {
+	if first > last {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	items := c.items[cachePartition{topic, partition}]
+	if last-first < uint64(len(items)) {
+		for msid := first; msid <= last; msid++ {
+			if e, ok := items[msid]; ok {
+				c.removeElement(e)
+			}
+		}
+		return
+	}
+	for msid, e := range items {
+		if msid >= first && msid <= last {
+			c.removeElement(e)
+		}
+	}
+}
+
+func (c *msgCache) removeElement(e *list.Element) {
+	entry := c.lru.Remove(e).(*cacheEntry)
+	items := c.items[entry.part]
+	delete(items, entry.m.Msid)
+	if len(items) == 0 {
+		delete(c.items, entry.part)
+	}
+	c.size -= entry.size
+	c.count--
+}
+
+// stats returns the hit and miss counters, the bytes and the number of
+// messages held.
+func (c *msgCache) stats() (uint64, uint64, int64, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return atomic.LoadUint64(&c.hits), atomic.LoadUint64(&c.misses), c.size, c.count
+}
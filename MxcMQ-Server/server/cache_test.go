@@ -0,0 +1,67 @@
+package server
+
+import (
+	"MxcMQ-Server/msg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMsgCache(t *testing.T) {
+	entry := int64(len("t")+len("payload")) + cacheEntryOverhead
+	c := newMsgCache(entry * 3)
+
+	for i := 1; i <= 3; i++ {
+		c.put("t", 1, &msg.MsgData{Msid: uint64(i), Payload: []byte("payload")})
+	}
+	m, ok := c.get("t", 1, 1)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), m.Msid)
+	// partitions do not share msids
+	_, ok = c.get("t", 2, 1)
+	assert.False(t, ok)
+
+	// 2 is the least recently used now
+	c.put("t", 1, &msg.MsgData{Msid: 4, Payload: []byte("payload")})
+	_, ok = c.get("t", 1, 2)
+	assert.False(t, ok)
+	_, ok = c.get("t", 1, 3)
+	assert.True(t, ok)
+
+	c.remove("t", 1, 3)
+	_, ok = c.get("t", 1, 3)
+	assert.False(t, ok)
+
+	hits, misses, size, n := c.stats()
+	assert.Equal(t, uint64(2), hits)
+	assert.Equal(t, uint64(3), misses)
+	assert.Equal(t, entry*2, size)
+	assert.Equal(t, 2, n)
+
+	// nothing is cached without a budget
+	c = newMsgCache(0)
+	c.put("t", 1, &msg.MsgData{Msid: 1})
+	_, ok = c.get("t", 1, 1)
+	assert.False(t, ok)
+}
+
+func TestMsgCacheRemoveRange(t *testing.T) {
+	c := newMsgCache(1 << 20)
+	for i := 1; i <= 10; i++ {
+		c.put("t", 1, &msg.MsgData{Msid: uint64(i)})
+		c.put("t", 2, &msg.MsgData{Msid: uint64(i)})
+	}
+
+	// a short range is walked by msid
+	c.removeRange("t", 1, 2, 3)
+	// a range wider than the cache walks the cached messages
+	c.removeRange("t", 1, 6, 1<<40)
+	for i := uint64(1); i <= 10; i++ {
+		_, ok := c.get("t", 1, i)
+		assert.Equal(t, i == 1 || i == 4 || i == 5, ok, "msid %v", i)
+		_, ok = c.get("t", 2, i)
+		assert.True(t, ok, "msid %v", i)
+	}
+	_, _, _, n := c.stats()
+	assert.Equal(t, 13, n)
+}
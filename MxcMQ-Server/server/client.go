@@ -412,7 +412,7 @@ func (c *client) processMsg(p []byte) {
 
 	mData := msg.MsgData{
 		Mid:     c.pa.Mid,
-		Payload: string(p),
+		Payload: p,
 	}
 	if err := c.srv.PutMsg(&c.pa, mData); err != nil {
 		//do something
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"MxcMQ-Server/logger"
+	"sort"
+)
+
+type keyLatest struct {
+	msid uint64
+	size int64
+}
+
+// compact removes every message of p which is followed by a newer one with
+// the same key, and the latest one too if it is a tombstone (empty payload)
+// every subscription has passed. Messages without a key are kept, the others
+// keep their msid. Each run goes on from the CompactOffset of the last one,
+// the latest msid of every key is kept in memory for that.
+func (s *Server) compact(p *partitionData) error {
+	tNode, err := s.meta.GetTopic(p.pNode.TopicName)
+	if err != nil {
+		return err
+	}
+	if !tNode.Retention.Compact {
+		return nil
+	}
+	// tombstones stay until no subscription is left to see them
+	floor, _, err := s.ackFloor(p)
+	if err != nil {
+		return err
+	}
+
+	// retention and compaction both free messages of p
+	p.deleteMu.Lock()
+	defer p.deleteMu.Unlock()
+
+	p.mu.Lock()
+	topic, partition := p.pNode.TopicName, p.pNode.ID
+	deleteOffset, offloadOffset, last := p.pNode.DeleteOffset, p.pNode.OffloadOffset, p.pNode.Mnum
+	first := p.pNode.CompactOffset + 1
+	if p.compacted == nil {
+		// first run since the broker owns p, the keys are read again
+		p.compacted = make(map[string]keyLatest)
+		first = deleteOffset + 1
+	}
+	p.mu.Unlock()
+	// offloaded messages are immutable
+	gone := deleteOffset
+	if offloadOffset > gone {
+		gone = offloadOffset
+	}
+	if first <= gone {
+		first = gone + 1
+	}
+
+	var drop []uint64
+	var freed int64
+	for i := first; i <= last; i += reapBatchSize {
+		msgs, err := s.store.Read(topic, partition, i, i+reapBatchSize-1)
+		if err != nil {
+			return err
+		}
+		for _, m := range msgs {
+			if m.Msid > last {
+				break
+			}
+			if m.Key == "" {
+				continue
+			}
+			if old, ok := p.compacted[m.Key]; ok && old.msid > gone {
+				drop = append(drop, old.msid)
+				freed += old.size
+			}
+			p.compacted[m.Key] = keyLatest{msid: m.Msid, size: int64(len(m.Payload))}
+		}
+	}
+	for key, l := range p.compacted {
+		switch {
+		case l.msid <= gone:
+			delete(p.compacted, key)
+		case l.size == 0 && l.msid <= floor:
+			drop = append(drop, l.msid)
+			delete(p.compacted, key)
+		}
+	}
+	if len(drop) > 0 {
+		sort.Slice(drop, func(i, j int) bool { return drop[i] < drop[j] })
+		if err := s.store.Remove(topic, partition, drop...); err != nil {
+			// the keys are read again by the next run
+			p.compacted = nil
+			return err
+		}
+		s.cache.remove(topic, partition, drop...)
+		logger.Infof("compact %v messages of %v/p%v up to %v", len(drop), topic, partition, last)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if last > p.pNode.CompactOffset {
+		p.pNode.CompactOffset = last
+	}
+	p.pNode.Size -= freed
+	if p.pNode.Size < 0 {
+		p.pNode.Size = 0
+	}
+	return s.meta.UpdatePartition(p.pNode)
+}
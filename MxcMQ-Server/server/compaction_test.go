@@ -0,0 +1,66 @@
+package server
+
+import (
+	"MxcMQ-Server/msg"
+	rc "MxcMQ-Server/registrationCenter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompact(t *testing.T) {
+	s, p := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1, Retention: rc.RetentionPolicy{Compact: true}})
+	publish := func(key, value string) {
+		p.pNode.Mnum++
+		p.pNode.Size += int64(len(value))
+		assert.Nil(t, s.store.Append("t", 1, &msg.MsgData{Msid: p.pNode.Mnum, Key: key, Payload: []byte(value)}))
+	}
+	publish("a", "a1")
+	publish("b", "b1")
+	publish("a", "a2")
+	publish("", "no key")
+	publish("b", "")
+	publish("a", "a3")
+	sub := addSub(t, s, "t", "s1", Exclusive)
+
+	// b is deleted but s1 did not see it yet
+	assert.Nil(t, s.compact(p))
+	assert.Equal(t, uint64(6), p.pNode.CompactOffset)
+	msgs, err := s.store.Read("t", 1, 1, 6)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{4, 5, 6}, msids(msgs))
+	assert.Equal(t, int64(8), p.pNode.Size)
+
+	// the compacted part is read at the original msids
+	conn := addConsumer(s, sub, "c1")
+	pull(t, s, sub, "c1", 3)
+	assert.Equal(t, []uint64{4, 5, 6}, conn.msids())
+	assert.Equal(t, uint64(7), sub.Data.PushOffset)
+
+	// the tombstone goes once s1 passed it, the next run reads only what is
+	// new and still drops a3
+	sub.ackCumulative(6)
+	publish("a", "a4")
+	publish("c", "c1")
+	assert.Nil(t, s.compact(p))
+	assert.Equal(t, uint64(8), p.pNode.CompactOffset)
+	msgs, err = s.store.Read("t", 1, 1, 8)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{4, 7, 8}, msids(msgs))
+
+	// a broker taking p over reads the keys again
+	p.compacted = nil
+	publish("c", "c2")
+	assert.Nil(t, s.compact(p))
+	msgs, err = s.store.Read("t", 1, 1, 9)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{4, 7, 9}, msids(msgs))
+}
+
+func msids(msgs []*msg.MsgData) []uint64 {
+	var ids []uint64
+	for _, m := range msgs {
+		ids = append(ids, m.Msid)
+	}
+	return ids
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"MxcMQ-Server/codec"
+	"MxcMQ-Server/msg"
+	pb "MxcMQ-Server/proto"
+	"encoding/base64"
+	"unicode/utf8"
+)
+
+// payloadEncodingKey is the property marking payloads sent base64 encoded
+// to legacy clients. Those clients predate it and do not decode the payload,
+// the application gets the base64 text and has to look at the property
+// itself. Clients which set BinaryPayload never get such payloads.
+const payloadEncodingKey = "MxcMQ-Payload-Encoding"
+
+// textPayload makes args readable for clients built when payloads were proto
+// strings: they know neither compression nor payloads which are not UTF-8.
+func textPayload(args *pb.MsgArgs) error {
+	payload, err := codec.Decompress(codec.CompressionType(args.Compression), args.Payload, maxMessageSize())
+	if err != nil {
+		return err
+	}
+	if !utf8.Valid(payload) {
+		payload = []byte(base64.StdEncoding.EncodeToString(payload))
+		props := make(map[string]string, len(args.Properties)+1)
+		for k, v := range args.Properties {
+			props[k] = v
+		}
+		props[payloadEncodingKey] = "base64"
+		args.Properties = props
+	}
+	args.Payload = payload
+	args.Compression = int32(codec.Compression_None)
+	args.Crc = msg.Checksum(payload)
+	return nil
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"MxcMQ-Server/codec"
+	pb "MxcMQ-Server/proto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextPayload(t *testing.T) {
+	data, err := codec.Compress(codec.Compression_Gzip, []byte("text"))
+	assert.Nil(t, err)
+	args := &pb.MsgArgs{Payload: data, Compression: int32(codec.Compression_Gzip)}
+	assert.Nil(t, textPayload(args))
+	assert.Equal(t, []byte("text"), args.Payload)
+	assert.Equal(t, int32(codec.Compression_None), args.Compression)
+	assert.Empty(t, args.Properties)
+
+	props := map[string]string{"k": "v"}
+	args = &pb.MsgArgs{Payload: []byte{0xff, 0x00}, Properties: props}
+	assert.Nil(t, textPayload(args))
+	assert.Equal(t, []byte("/wA="), args.Payload)
+	assert.Equal(t, "base64", args.Properties[payloadEncodingKey])
+	assert.Equal(t, "v", args.Properties["k"])
+	assert.Len(t, props, 1)
+}
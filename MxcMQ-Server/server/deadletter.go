@@ -0,0 +1,272 @@
+package server
+
+import (
+	"MxcMQ-Server/config"
+	"MxcMQ-Server/logger"
+	"MxcMQ-Server/msg"
+	pb "MxcMQ-Server/proto"
+	rc "MxcMQ-Server/registrationCenter"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// properties added to dead lettered messages
+const (
+	deadLetterReason       = "MxcMQ-DeadLetter-Reason"
+	deadLetterDeliveries   = "MxcMQ-DeadLetter-Deliveries"
+	deadLetterTopic        = "MxcMQ-Origin-Topic"
+	deadLetterPartition    = "MxcMQ-Origin-Partition"
+	deadLetterMsid         = "MxcMQ-Origin-Msid"
+	deadLetterSubscription = "MxcMQ-Origin-Subscription"
+)
+
+type DeadLetterPolicy struct {
+	MaxDeliveries int    // 0 means messages are redelivered until acked
+	Topic         string // <topic>-<subscription>-DLQ if empty
+}
+
+// delivery counts the failed deliveries of a message not acked yet.
+type delivery struct {
+	count      int
+	reason     string
+	consumer   string // which let the ack timeout pass
+	avoidUntil int64  // unix millisecond
+}
+
+func (p DeadLetterPolicy) topic(meta rc.SubcriptionNode) string {
+	if p.Topic != "" {
+		return p.Topic
+	}
+	return fmt.Sprintf("%v-%v-DLQ", meta.TopicName, meta.Name)
+}
+
+// redeliveryCount returns how many times msid failed to be delivered.
+func (sub *subcription) redeliveryCount(msid uint64) int {
+	if d, ok := sub.deliveries[msid]; ok {
+		return d.count
+	}
+	return 0
+}
+
+// redeliver queues msid to be delivered again before the cursor moves on.
+func (sub *subcription) redeliver(msid uint64) {
+	i := sort.Search(len(sub.redeliveries), func(i int) bool { return sub.redeliveries[i] >= msid })
+	if i < len(sub.redeliveries) && sub.redeliveries[i] == msid {
+		return
+	}
+	sub.redeliveries = append(sub.redeliveries, 0)
+	copy(sub.redeliveries[i+1:], sub.redeliveries[i:])
+	sub.redeliveries[i] = msid
+}
+
+func (sub *subcription) nextRedelivery() (uint64, bool) {
+	if len(sub.redeliveries) == 0 {
+		return 0, false
+	}
+	msid := sub.redeliveries[0]
+	sub.redeliveries = sub.redeliveries[1:]
+	return msid, true
+}
+
+// forgetAcked drops the delivery counts and ack timeouts of the messages
+// from from to to, the ones before from are gone already. It walks the range
+// or the tracked messages, whichever is shorter.
+func (sub *subcription) forgetAcked(from, to uint64) {
+	if from > to {
+		return
+	}
+	if to-from < uint64(len(sub.deliveries)+len(sub.inflight)) {
+		for msid := from; ; msid++ {
+			delete(sub.deliveries, msid)
+			delete(sub.inflight, msid)
+			if msid == to {
+				return
+			}
+		}
+	}
+	for msid := range sub.deliveries {
+		if msid >= from && msid <= to {
+			delete(sub.deliveries, msid)
+		}
+	}
+	for msid := range sub.inflight {
+		if msid >= from && msid <= to {
+			delete(sub.inflight, msid)
+		}
+	}
+}
+
+// failed counts a failed delivery of msid, it tells if msid is to be dead
+// lettered.
+func (sub *subcription) failed(msid uint64, reason string) (int, bool) {
+	if sub.isAcked(msid) {
+		// acked meanwhile, nothing left to count
+		return 0, false
+	}
+	d, ok := sub.deliveries[msid]
+	if !ok {
+		d = &delivery{}
+		sub.deliveries[msid] = d
+	}
+	d.count++
+	d.reason = reason
+	max := sub.Data.DeadLetter.MaxDeliveries
+	return d.count, max > 0 && d.count >= max
+}
+
+// deliveryFailed queues m for redelivery, or publishes it to the dead letter
+// topic of sub once it failed MaxDeliveries times.
+func (s *Server) deliveryFailed(sub *subcription, m *msg.MsgData, reason string) {
+	sub.mu.Lock()
+	count, dead := sub.failed(m.Msid, reason)
+	if !dead {
+		sub.redeliver(m.Msid)
+	}
+	sub.mu.Unlock()
+	if dead {
+		s.deadLetterOf(sub, m, count, reason)
+	}
+}
+
+// deadLetterMsids loads and dead letters the given messages of sub.
+func (s *Server) deadLetterMsids(pua *msg.PullArg, sub *subcription, msids map[uint64]int, reason string) {
+	for msid, count := range msids {
+		m, err := s.loadMsg(pua, msid)
+		if err != nil {
+			logger.Errorf("load message %v to dead letter failed: %v", msid, err)
+			continue
+		}
+		s.deadLetterOf(sub, m, count, reason)
+	}
+}
+
+// deadLetterOf publishes m to the dead letter topic of sub, m is redelivered
+// if it can not.
+func (s *Server) deadLetterOf(sub *subcription, m *msg.MsgData, count int, reason string) {
+	sub.mu.Lock()
+	policy, meta := sub.Data.DeadLetter, sub.Data.Meta
+	sub.mu.Unlock()
+
+	if err := s.deadLetter(policy.topic(meta), meta, m, count, reason); err != nil {
+		// it is dead lettered again after the next failure
+		logger.Errorf("dead letter %v/%v/%v failed: %v", meta.TopicName, meta.Partition, m.Msid, err)
+		sub.mu.Lock()
+		sub.redeliver(m.Msid)
+		sub.mu.Unlock()
+		return
+	}
+	logger.Infof("dead letter %v/%v/%v to %v after %v deliveries: %v", meta.TopicName, meta.Partition, m.Msid, policy.topic(meta), count, reason)
+	sub.mu.Lock()
+	sub.ack(m.Msid)
+	delete(sub.deliveries, m.Msid)
+	sub.mu.Unlock()
+}
+
+// deadLetter publishes m with its properties and why it failed to the first
+// partition of topic, which is created if needed.
+func (s *Server) deadLetter(topic string, meta rc.SubcriptionNode, m *msg.MsgData, count int, reason string) error {
+	props := make(map[string]string, len(m.Properties)+6)
+	for k, v := range m.Properties {
+		props[k] = v
+	}
+	props[deadLetterReason] = reason
+	props[deadLetterDeliveries] = strconv.Itoa(count)
+	props[deadLetterTopic] = meta.TopicName
+	props[deadLetterPartition] = strconv.Itoa(meta.Partition)
+	props[deadLetterMsid] = strconv.FormatUint(m.Msid, 10)
+	props[deadLetterSubscription] = meta.Name
+
+	if err := s.ensureTopic(topic); err != nil {
+		return err
+	}
+	args := &pb.PublishArgs{
+		Topic:        topic,
+		Partition:    1,
+		Mid:          m.Mid,
+		Key:          m.Key,
+		Payload:      m.Payload,
+		Compression:  int32(m.Compression),
+		Crc:          m.Crc,
+		Properties:   props,
+		EventTime:    m.EventTime,
+		ProducerName: m.ProducerName,
+	}
+	return s.publish(args)
+}
+
+// ensureTopic registers topic with one partition if it does not exist.
+func (s *Server) ensureTopic(topic string) error {
+	isExists, err := s.meta.IsTopicExists(topic)
+	if err != nil || isExists {
+		return err
+	}
+	tNode := &rc.TopicNode{
+		Name: topic,
+		Pnum: 1,
+		Retention: rc.RetentionPolicy{
+			MaxAge:      config.SrvConf.DefaultRetentionMaxAge,
+			MaxBytes:    config.SrvConf.DefaultRetentionMaxBytes,
+			MaxMsgs:     config.SrvConf.DefaultRetentionMaxMsgs,
+			DeleteAcked: config.SrvConf.DefaultDeleteAcked,
+		},
+		MessageTTL: config.SrvConf.DefaultMessageTTL,
+	}
+	if err := s.registerTopic(tNode); err != nil && err != zk.ErrNodeExists {
+		return err
+	}
+	pNode := &rc.PartitionNode{ID: 1, TopicName: topic}
+	if err := s.meta.RegisterPnode(pNode); err != nil && err != zk.ErrNodeExists {
+		return err
+	}
+	return nil
+}
+
+// publish hands args to the broker owning its partition, which may be this one.
+func (s *Server) publish(args *pb.PublishArgs) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(config.SrvConf.OperationTimeout))
+	defer cancel()
+	url, err := s.ownerOf(ctx, args.Topic, args.Partition)
+	if err != nil {
+		return err
+	}
+	if url == fmt.Sprintf("%v:%v", s.Info.Host, s.Info.Port) {
+		_, err = s.ProcessPub(ctx, args)
+		return err
+	}
+	conn, err := grpc.Dial(url, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = pb.NewServerClient(conn).ProcessPub(ctx, args)
+	return err
+}
+
+// ownerOf returns the url of the broker of a partition, it asks the leader
+// to allocate the bundle if nobody owns it yet.
+func (s *Server) ownerOf(ctx context.Context, topic string, partition int32) (string, error) {
+	reply, err := s.LookUp(ctx, &pb.LookUpArgs{Topic: topic, Partition: partition})
+	if err == nil {
+		return reply.Url, nil
+	}
+	if reply == nil || reply.Url == "" {
+		return "", err
+	}
+	conn, err := grpc.Dial(reply.Url, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	allocReply, err := pb.NewServerClient(conn).RequestAlloc(ctx, &pb.RequestAllocArgs{Topic: topic, Partition: partition})
+	if err != nil {
+		return "", err
+	}
+	return allocReply.Url, nil
+}
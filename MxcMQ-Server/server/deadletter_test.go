@@ -0,0 +1,36 @@
+package server
+
+import (
+	"MxcMQ-Server/msg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedeliver(t *testing.T) {
+	s := &Server{}
+	sub := NewSubcription()
+	sub.Data.Meta.TopicName = "t"
+	sub.Data.Meta.Name = "sub"
+	sub.Data.DeadLetter.MaxDeliveries = 3
+	assert.Equal(t, "t-sub-DLQ", sub.Data.DeadLetter.topic(sub.Data.Meta))
+
+	s.deliveryFailed(sub, &msg.MsgData{Msid: 5}, "timeout")
+	s.deliveryFailed(sub, &msg.MsgData{Msid: 2}, "timeout")
+	s.deliveryFailed(sub, &msg.MsgData{Msid: 5}, "unavailable")
+	assert.Equal(t, 2, sub.redeliveryCount(5))
+	assert.Equal(t, "unavailable", sub.deliveries[5].reason)
+
+	// oldest first, once each
+	msid, ok := sub.nextRedelivery()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), msid)
+	msid, _ = sub.nextRedelivery()
+	assert.Equal(t, uint64(5), msid)
+	_, ok = sub.nextRedelivery()
+	assert.False(t, ok)
+
+	sub.forgetAcked(1, 4)
+	assert.Equal(t, 0, sub.redeliveryCount(2))
+	assert.Equal(t, 2, sub.redeliveryCount(5))
+}
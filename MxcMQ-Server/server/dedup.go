@@ -0,0 +1,173 @@
+package server
+
+import (
+	"MxcMQ-Server/config"
+	"MxcMQ-Server/logger"
+	"MxcMQ-Server/msg"
+	rc "MxcMQ-Server/registrationCenter"
+	"fmt"
+	"time"
+)
+
+// dedup drops the msgs whose sequence id their producer persisted on pNode
+// before, or sent earlier in msgs. It returns the msgs to write and the last
+// sequence id of each producer once they are written. The dropped msgs keep
+// Msid 0.
+func dedup(pNode *rc.PartitionNode, msgs []*msg.MsgData) ([]*msg.MsgData, map[string]int64) {
+	if !config.SrvConf.BrokerDeduplicationEnabled {
+		return msgs, nil
+	}
+	kept := make([]*msg.MsgData, 0, len(msgs))
+	seqs := make(map[string]int64)
+	for _, m := range msgs {
+		if m.SequenceId <= 0 || m.ProducerName == "" {
+			kept = append(kept, m)
+			continue
+		}
+		last, ok := seqs[m.ProducerName]
+		if !ok {
+			last = pNode.Sequences[m.ProducerName]
+		}
+		if m.SequenceId <= last {
+			logger.Infof("drop duplicate publish %v of %v on %v/%v", m.SequenceId, m.ProducerName, pNode.TopicName, pNode.ID)
+			continue
+		}
+		seqs[m.ProducerName] = m.SequenceId
+		kept = append(kept, m)
+	}
+	return kept, seqs
+}
+
+// persistSequences records seqs as the last sequence ids persisted on pNode
+// at now.
+func persistSequences(pNode *rc.PartitionNode, seqs map[string]int64, now int64) {
+	if len(seqs) == 0 {
+		return
+	}
+	if pNode.Sequences == nil {
+		pNode.Sequences = make(map[string]int64)
+	}
+	if pNode.SequenceTimes == nil {
+		pNode.SequenceTimes = make(map[string]int64)
+	}
+	for producer, seq := range seqs {
+		if seq > pNode.Sequences[producer] {
+			pNode.Sequences[producer] = seq
+		}
+		pNode.SequenceTimes[producer] = now
+	}
+}
+
+// producerSeq is what pNode holds for a producer.
+type producerSeq struct {
+	seq int64
+	at  int64
+}
+
+// sequencesOf returns what pNode holds for the producers of seqs.
+func sequencesOf(pNode *rc.PartitionNode, seqs map[string]int64) map[string]producerSeq {
+	prev := make(map[string]producerSeq, len(seqs))
+	for producer := range seqs {
+		prev[producer] = producerSeq{pNode.Sequences[producer], pNode.SequenceTimes[producer]}
+	}
+	return prev
+}
+
+// restoreSequences undoes persistSequences with what sequencesOf returned.
+func restoreSequences(pNode *rc.PartitionNode, prev map[string]producerSeq) {
+	for producer, p := range prev {
+		if p.seq == 0 {
+			delete(pNode.Sequences, producer)
+		} else {
+			pNode.Sequences[producer] = p.seq
+		}
+		if p.at == 0 {
+			delete(pNode.SequenceTimes, producer)
+		} else {
+			pNode.SequenceTimes[producer] = p.at
+		}
+	}
+}
+
+// pruneSequences forgets the producers of pNode which did not publish for
+// window seconds, one recorded without a time starts its window at now. It
+// returns what it changed for restoreSequences.
+func pruneSequences(pNode *rc.PartitionNode, window int64, now int64) map[string]producerSeq {
+	if window <= 0 {
+		return nil
+	}
+	prev := make(map[string]producerSeq)
+	for producer, seq := range pNode.Sequences {
+		at, ok := pNode.SequenceTimes[producer]
+		if !ok {
+			if pNode.SequenceTimes == nil {
+				pNode.SequenceTimes = make(map[string]int64)
+			}
+			prev[producer] = producerSeq{seq, 0}
+			pNode.SequenceTimes[producer] = now
+			continue
+		}
+		if at+window*1000 <= now {
+			prev[producer] = producerSeq{seq, at}
+			delete(pNode.Sequences, producer)
+			delete(pNode.SequenceTimes, producer)
+		}
+	}
+	return prev
+}
+
+// forgetInactiveProducers prunes the sequence ids of p, a producer coming
+// back after that publishes as a new one.
+func (s *Server) forgetInactiveProducers(p *partitionData) {
+	if !config.SrvConf.BrokerDeduplicationEnabled {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	version := p.pNode.Version
+	prev := pruneSequences(p.pNode, config.SrvConf.DeduplicationProducerInactivity, time.Now().UnixMilli())
+	if len(prev) == 0 {
+		return
+	}
+	if err := s.meta.UpdatePartition(p.pNode); err != nil {
+		logger.Errorf("UpdatePartition failed: %v", err)
+		p.pNode.Version = version
+		restoreSequences(p.pNode, prev)
+	}
+}
+
+// recoverSequences reads the sequence ids of the messages from up to to,
+// which the store holds but zk did not record before the broker stopped.
+func (s *Server) recoverSequences(pNode *rc.PartitionNode, from, to uint64) error {
+	if !config.SrvConf.BrokerDeduplicationEnabled {
+		return nil
+	}
+	msgs, err := s.readMsgs(pNode.TopicName, pNode.ID, from, to)
+	if err != nil {
+		return err
+	}
+	seqs := make(map[string]int64)
+	for _, m := range msgs {
+		if m.SequenceId > seqs[m.ProducerName] && m.ProducerName != "" {
+			seqs[m.ProducerName] = m.SequenceId
+		}
+	}
+	persistSequences(pNode, seqs, time.Now().UnixMilli())
+	return nil
+}
+
+// lastSequenceId returns the last sequence id producer persisted on the
+// partition, its next publish goes on from there.
+func (s *Server) lastSequenceId(topic string, partition int, producer string) (int64, error) {
+	if v, ok := s.partitions.Load(fmt.Sprintf(partitionKey, topic, partition)); ok {
+		p := v.(*partitionData)
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.pNode.Sequences[producer], nil
+	}
+	pNode, err := s.meta.GetPartition(topic, partition)
+	if err != nil {
+		return 0, err
+	}
+	return pNode.Sequences[producer], nil
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"MxcMQ-Server/config"
+	"MxcMQ-Server/msg"
+	"MxcMQ-Server/persist"
+	pb "MxcMQ-Server/proto"
+	rc "MxcMQ-Server/registrationCenter"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedup(t *testing.T) {
+	config.SrvConf.BrokerDeduplicationEnabled = true
+	defer func() { config.SrvConf.BrokerDeduplicationEnabled = false }()
+
+	pNode := &rc.PartitionNode{TopicName: "t", ID: 1, Sequences: map[string]int64{"p1": 5}}
+	msgs := []*msg.MsgData{
+		{ProducerName: "p1", SequenceId: 5},
+		{ProducerName: "p1", SequenceId: 6},
+		{ProducerName: "p2", SequenceId: 1},
+		{ProducerName: "p1", SequenceId: 6},
+		{ProducerName: "p1"},
+	}
+	kept, seqs := dedup(pNode, msgs)
+	assert.Equal(t, []*msg.MsgData{msgs[1], msgs[2], msgs[4]}, kept)
+	assert.Equal(t, map[string]int64{"p1": 6, "p2": 1}, seqs)
+	// nothing counts before it is written
+	assert.Equal(t, int64(5), pNode.Sequences["p1"])
+
+	persistSequences(pNode, seqs, 1000)
+	assert.Equal(t, map[string]int64{"p1": 6, "p2": 1}, pNode.Sequences)
+	assert.Equal(t, map[string]int64{"p1": 1000, "p2": 1000}, pNode.SequenceTimes)
+	kept, _ = dedup(pNode, []*msg.MsgData{{ProducerName: "p2", SequenceId: 1}})
+	assert.Empty(t, kept)
+
+	config.SrvConf.BrokerDeduplicationEnabled = false
+	kept, _ = dedup(pNode, msgs)
+	assert.Equal(t, msgs, kept)
+}
+
+func TestRecoverSequences(t *testing.T) {
+	config.SrvConf.BrokerDeduplicationEnabled = true
+	defer func() { config.SrvConf.BrokerDeduplicationEnabled = false }()
+
+	s := &Server{store: persist.NewMemoryStore()}
+	for i, seq := range []int64{1, 2, 3, 7} {
+		s.store.Append("t", 1, &msg.MsgData{Msid: uint64(i + 1), ProducerName: "p1", SequenceId: seq})
+	}
+
+	// zk recorded the first two messages only
+	pNode := &rc.PartitionNode{TopicName: "t", ID: 1, Mnum: 2, Sequences: map[string]int64{"p1": 2}}
+	assert.Nil(t, s.recoverSequences(pNode, 3, 4))
+	assert.Equal(t, int64(7), pNode.Sequences["p1"])
+}
+
+func TestPruneSequences(t *testing.T) {
+	config.SrvConf.BrokerDeduplicationEnabled = true
+	defer func() { config.SrvConf.BrokerDeduplicationEnabled = false }()
+
+	pNode := &rc.PartitionNode{
+		TopicName:     "t",
+		ID:            1,
+		Sequences:     map[string]int64{"p1": 5, "p2": 3, "p3": 7},
+		SequenceTimes: map[string]int64{"p1": 1000, "p2": 8000},
+	}
+	assert.Nil(t, pruneSequences(pNode, 0, 20000))
+
+	// p3 was recorded before times were, its window starts now
+	prev := pruneSequences(pNode, 10, 12000)
+	assert.Equal(t, map[string]int64{"p2": 3, "p3": 7}, pNode.Sequences)
+	assert.Equal(t, map[string]int64{"p2": 8000, "p3": 12000}, pNode.SequenceTimes)
+	restoreSequences(pNode, prev)
+	assert.Equal(t, map[string]int64{"p1": 5, "p2": 3, "p3": 7}, pNode.Sequences)
+	assert.Equal(t, map[string]int64{"p1": 1000, "p2": 8000}, pNode.SequenceTimes)
+
+	// a failed update keeps them all
+	s, p := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1})
+	config.SrvConf.DeduplicationProducerInactivity = 10
+	defer func() { config.SrvConf.DeduplicationProducerInactivity = 0 }()
+	p.pNode.Sequences = map[string]int64{"p1": 5, "p2": 3}
+	p.pNode.SequenceTimes = map[string]int64{"p1": 1000, "p2": time.Now().UnixMilli()}
+	s.meta.(*memMeta).failUpdate = errors.New("connection lost")
+	s.forgetInactiveProducers(p)
+	assert.Equal(t, map[string]int64{"p1": 5, "p2": 3}, p.pNode.Sequences)
+
+	s.meta.(*memMeta).failUpdate = nil
+	s.forgetInactiveProducers(p)
+	assert.Equal(t, map[string]int64{"p2": 3}, p.pNode.Sequences)
+	stored, err := s.meta.GetPartition("t", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{"p2": 3}, stored.Sequences)
+
+	// a pruned producer publishes as a new one
+	assert.Nil(t, s.commitMsgs(p, &msg.MsgData{ProducerName: "p1", SequenceId: 1}))
+	assert.Equal(t, uint64(1), p.pNode.Mnum)
+}
+
+func TestDedupSameName(t *testing.T) {
+	config.SrvConf.BrokerDeduplicationEnabled = true
+	config.SrvConf.AllowRenameForClient = true
+	defer func() {
+		config.SrvConf.BrokerDeduplicationEnabled = false
+		config.SrvConf.AllowRenameForClient = false
+	}()
+
+	s, p := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1})
+	first := &pb.ConnectArgs{Name: "p1", Topic: "t", Partition: 1, Type: Puber}
+	name, err := s.connName("t-publisher-p1", first)
+	assert.Nil(t, err)
+	s.conns.Store(name, nil)
+	assert.Nil(t, s.commitMsgs(p, &msg.MsgData{ProducerName: "p1", SequenceId: 1}))
+
+	// the second one would publish under the sequence ids of the first
+	second := &pb.ConnectArgs{Name: "p1", Topic: "t", Partition: 1, Type: PartPuber}
+	_, err = s.connName("t-publisher-p1", second)
+	assert.NotNil(t, err)
+
+	// once the first is gone it takes over where the first stopped
+	s.conns.Delete(name)
+	name, err = s.connName("t-publisher-p1", second)
+	assert.Nil(t, err)
+	assert.Equal(t, "t-publisher-p1", name)
+	seq, err := s.lastSequenceId("t", 1, second.Name)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), seq)
+
+	// subscribers and producers without deduplication are renamed
+	s.conns.Store(name, nil)
+	s.conns.Store("t-subscriber-p1", nil)
+	name, err = s.connName("t-subscriber-p1", &pb.ConnectArgs{Name: "p1", Type: Suber})
+	assert.Nil(t, err)
+	assert.Equal(t, "t-subscriber-p1(1)", name)
+	config.SrvConf.BrokerDeduplicationEnabled = false
+	name, err = s.connName("t-publisher-p1", second)
+	assert.Nil(t, err)
+	assert.Equal(t, "t-publisher-p1(1)", name)
+}
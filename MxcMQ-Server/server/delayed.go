@@ -0,0 +1,101 @@
+package server
+
+import (
+	"MxcMQ-Server/logger"
+	"MxcMQ-Server/msg"
+	"container/heap"
+	"fmt"
+)
+
+// number of messages read from the store per batch when the delayed index
+// is rebuilt
+const delayedRebuildBatch = 1000
+
+type delayedMsg struct {
+	at   int64 // unix millisecond
+	msid uint64
+}
+
+// delayedIndex holds the messages the cursor of a subscription went past
+// before their deliver time, earliest first.
+type delayedIndex []delayedMsg
+
+func (d delayedIndex) Len() int { return len(d) }
+func (d delayedIndex) Less(i, j int) bool {
+	return d[i].at < d[j].at || (d[i].at == d[j].at && d[i].msid < d[j].msid)
+}
+func (d delayedIndex) Swap(i, j int)       { d[i], d[j] = d[j], d[i] }
+func (d *delayedIndex) Push(x interface{}) { *d = append(*d, x.(delayedMsg)) }
+func (d *delayedIndex) Pop() interface{} {
+	old := *d
+	x := old[len(old)-1]
+	*d = old[:len(old)-1]
+	return x
+}
+
+// delay keeps m until it is due. DelayedFloor is the lowest msid in the
+// index, the index is rebuilt from there after a restart.
+func (sub *subcription) delay(m *msg.MsgData) {
+	heap.Push(&sub.delayed, delayedMsg{at: m.DeliverAt, msid: m.Msid})
+	if sub.Data.DelayedFloor == 0 || m.Msid < sub.Data.DelayedFloor {
+		sub.Data.DelayedFloor = m.Msid
+	}
+}
+
+// due pops the earliest delayed message if its time has come. Messages are
+// due in (deliver time, msid) order, the last one is kept in DelayedAt and
+// DelayedMsid so a rebuilt index skips what was delivered already.
+func (sub *subcription) due(now int64) (uint64, bool) {
+	if len(sub.delayed) == 0 || sub.delayed[0].at > now {
+		return 0, false
+	}
+	d := heap.Pop(&sub.delayed).(delayedMsg)
+	sub.Data.DelayedAt, sub.Data.DelayedMsid = d.at, d.msid
+	if d.msid == sub.Data.DelayedFloor {
+		sub.Data.DelayedFloor = 0
+		for _, r := range sub.delayed {
+			if sub.Data.DelayedFloor == 0 || r.msid < sub.Data.DelayedFloor {
+				sub.Data.DelayedFloor = r.msid
+			}
+		}
+	}
+	return d.msid, true
+}
+
+// delivered tells if the delayed message m was handed out before the index
+// was lost.
+func (sub *subcription) delivered(m *msg.MsgData) bool {
+	return m.DeliverAt < sub.Data.DelayedAt || (m.DeliverAt == sub.Data.DelayedAt && m.Msid <= sub.Data.DelayedMsid)
+}
+
+// rebuildDelayed reads again the messages between DelayedFloor and the
+// cursor of sub to find the delayed ones not delivered yet. A message which
+// was due when the cursor read it may be delivered once more.
+func (s *Server) rebuildDelayed(sub *subcription) error {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	sub.delayed = nil
+	floor := sub.Data.DelayedFloor
+	if floor == 0 {
+		return nil
+	}
+	sub.Data.DelayedFloor = 0
+	meta := sub.Data.Meta
+	for start := floor; start < sub.Data.PushOffset; start += delayedRebuildBatch {
+		end := start + delayedRebuildBatch - 1
+		if end >= sub.Data.PushOffset {
+			end = sub.Data.PushOffset - 1
+		}
+		msgs, err := s.readMsgs(meta.TopicName, meta.Partition, start, end)
+		if err != nil {
+			return fmt.Errorf("rebuild delayed index of %v: %w", meta.Name, err)
+		}
+		for _, m := range msgs {
+			if m.DeliverAt != 0 && !sub.delivered(m) {
+				sub.delay(m)
+			}
+		}
+	}
+	logger.Infof("rebuild delayed index of %v/%v/%v: %v messages", meta.TopicName, meta.Partition, meta.Name, len(sub.delayed))
+	return nil
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"MxcMQ-Server/msg"
+	"MxcMQ-Server/persist"
+	rc "MxcMQ-Server/registrationCenter"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelayedIndex(t *testing.T) {
+	sub := NewSubcription()
+	sub.delay(&msg.MsgData{Msid: 3, DeliverAt: 300})
+	sub.delay(&msg.MsgData{Msid: 2, DeliverAt: 200})
+	sub.delay(&msg.MsgData{Msid: 4, DeliverAt: 200})
+	assert.Equal(t, uint64(2), sub.Data.DelayedFloor)
+
+	_, ok := sub.due(100)
+	assert.False(t, ok)
+	msid, ok := sub.due(250)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), msid)
+	assert.Equal(t, uint64(3), sub.Data.DelayedFloor)
+	msid, _ = sub.due(250)
+	assert.Equal(t, uint64(4), msid)
+	_, ok = sub.due(250)
+	assert.False(t, ok)
+
+	assert.True(t, sub.delivered(&msg.MsgData{Msid: 2, DeliverAt: 200}))
+	assert.False(t, sub.delivered(&msg.MsgData{Msid: 3, DeliverAt: 300}))
+}
+
+func TestRebuildDelayed(t *testing.T) {
+	s := &Server{store: persist.NewMemoryStore()}
+	for i, at := range []int64{0, 500, 0, 100, 400} {
+		s.store.Append("t", 1, &msg.MsgData{Msid: uint64(i + 1), DeliverAt: at})
+	}
+
+	sub := NewSubcription()
+	sub.Data.Meta.TopicName = "t"
+	sub.Data.Meta.Partition = 1
+	sub.Data.PushOffset = 6
+	sub.delay(&msg.MsgData{Msid: 2, DeliverAt: 500})
+	sub.delay(&msg.MsgData{Msid: 4, DeliverAt: 100})
+	sub.delay(&msg.MsgData{Msid: 5, DeliverAt: 400})
+	sub.due(100)
+
+	// the index is lost with the broker
+	sub.delayed = nil
+	assert.Nil(t, s.rebuildDelayed(sub))
+	assert.Equal(t, uint64(2), sub.Data.DelayedFloor)
+	msid, _ := sub.due(1000)
+	assert.Equal(t, uint64(5), msid)
+	msid, _ = sub.due(1000)
+	assert.Equal(t, uint64(2), msid)
+	_, ok := sub.due(1000)
+	assert.False(t, ok)
+}
+
+func TestDelayedSurvivesAck(t *testing.T) {
+	s, p := newRetentionServer(t, rc.RetentionPolicy{DeleteAcked: true}, 0)
+	sub := s.Sl.Subs[fmt.Sprintf(subcriptionKey, "t", 1, "s0")]
+	deliverAt := time.Now().Add(time.Hour).UnixMilli()
+	sub.delay(&msg.MsgData{Msid: 3, DeliverAt: deliverAt})
+
+	// the consumer acks what it got, 3 is still hidden
+	assert.True(t, sub.ackCumulative(8))
+	assert.Equal(t, uint64(2), sub.Data.AckOffset)
+	assert.Equal(t, []AckRange{{From: 4, To: 8}}, sub.Data.Acked)
+	assert.Nil(t, s.deleteAcked(p))
+	assert.Equal(t, uint64(2), p.pNode.DeleteOffset)
+	msgs, err := s.store.Read("t", 1, 3, 3)
+	assert.Nil(t, err)
+	assert.Len(t, msgs, 1)
+
+	msid, ok := sub.due(deliverAt)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), msid)
+	assert.False(t, sub.isAcked(3))
+	sub.ack(3)
+	assert.Equal(t, uint64(8), sub.Data.AckOffset)
+
+	// acked past a delayed message before, gc still waits for it
+	sub.Data.AckOffset = 9
+	sub.Data.DelayedFloor = 5
+	floor, _, err := s.ackFloor(p)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4), floor)
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"MxcMQ-Server/logger"
+	"sync/atomic"
+)
+
+// number of messages deleted at a time once acked by all subscriptions
+const gcBatchSize = 1000
+
+// collectAcked deletes the messages of p which every subscription has acked
+// if the topic is in DeleteAcked mode. At most one collection runs for p.
+func (s *Server) collectAcked(p *partitionData) {
+	if !atomic.CompareAndSwapInt32(&p.collecting, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&p.collecting, 0)
+		if err := s.deleteAcked(p); err != nil {
+			logger.Errorf("deleteAcked failed: %v", err)
+		}
+	}()
+}
+
+func (s *Server) deleteAcked(p *partitionData) error {
+	tNode, err := s.meta.GetTopic(p.pNode.TopicName)
+	if err != nil {
+		return err
+	}
+	if !tNode.Retention.DeleteAcked {
+		return nil
+	}
+
+	// nothing is consumed before the first subscription comes
+	floor, ok, err := s.ackFloor(p)
+	if err != nil || !ok {
+		return err
+	}
+	for {
+		p.mu.Lock()
+		first := p.pNode.DeleteOffset + 1
+		p.mu.Unlock()
+		if first > floor {
+			return nil
+		}
+
+		end := first + gcBatchSize - 1
+		if end > floor {
+			end = floor
+		}
+		if err := s.deleteBefore(p, end, func(first, last uint64, size int64) (uint64, int64, error) {
+			return s.payloadTo(p, first, end)
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// payloadTo returns end together with the payload bytes of p in [first, end].
+func (s *Server) payloadTo(p *partitionData, first, end uint64) (uint64, int64, error) {
+	msgs, err := s.readMsgs(p.pNode.TopicName, p.pNode.ID, first, end)
+	if err != nil {
+		return 0, 0, err
+	}
+	var size int64
+	for _, m := range msgs {
+		size += int64(len(m.Payload))
+	}
+	return end, size, nil
+}
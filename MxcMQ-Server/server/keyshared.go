@@ -0,0 +1,370 @@
+package server
+
+import (
+	"MxcMQ-Server/logger"
+	"MxcMQ-Server/msg"
+	"hash/crc32"
+	"sort"
+)
+
+const (
+	// the key hash space split among the consumers of a Key_Shared subscription
+	keyHashRange = 1 << 16
+	// messages held in memory for one consumer, the msids of the next ones
+	// are kept and their messages read again once it caught up
+	keySharedMaxPending = 1000
+	// messages read back from the store at a time for one consumer
+	keySharedRefillMsgs = 100
+)
+
+type keyRange struct {
+	start, end int // [start, end)
+	consumer   string
+}
+
+// movedRange is a range of key hashes a consumer took over from another one,
+// its keys wait until everything dispatched up to until is acked.
+type movedRange struct {
+	start, end int
+	until      uint64
+}
+
+// heldRef is a message held for a consumer past keySharedMaxPending.
+type heldRef struct {
+	msid uint64
+	key  string
+}
+
+// keyShared dispatches the messages of a Key_Shared subscription, a message
+// goes to the consumer whose range holds the hash of its key, messages
+// without key go to whoever pulls them. Messages read from the cursor by
+// another consumer are held until the owner pulls, a slow owner does not
+// stop the cursor for the others: past keySharedMaxPending only the msids of
+// its messages are held.
+//
+// A consumer whose ranges grew, by joining or when another one left, may
+// get a key while older messages of it are still unacked by the previous
+// owner, so the keys of the ranges it took over wait until everything
+// dispatched before the rebalance is acked. Its other keys go on.
+type keyShared struct {
+	ranges   []keyRange // sorted, covering [0, keyHashRange) if not empty
+	pending  map[string][]*msg.MsgData
+	overflow map[string][]heldRef // sorted by msid
+	moved    map[string][]movedRange
+}
+
+func newKeyShared() *keyShared {
+	return &keyShared{
+		pending:  make(map[string][]*msg.MsgData),
+		overflow: make(map[string][]heldRef),
+		moved:    make(map[string][]movedRange),
+	}
+}
+
+func keyHash(key string) int {
+	return int(crc32.ChecksumIEEE([]byte(key)) % keyHashRange)
+}
+
+func (ks *keyShared) owner(key string) (string, bool) {
+	h := keyHash(key)
+	i := sort.Search(len(ks.ranges), func(i int) bool { return ks.ranges[i].end > h })
+	if i == len(ks.ranges) {
+		return "", false
+	}
+	return ks.ranges[i].consumer, true
+}
+
+func (ks *keyShared) isConsumer(consumer string) bool {
+	for _, r := range ks.ranges {
+		if r.consumer == consumer {
+			return true
+		}
+	}
+	return false
+}
+
+// join gives consumer the upper half of the largest range, dispatched is
+// the last msid read from the cursor.
+func (ks *keyShared) join(consumer string, dispatched uint64) {
+	if ks.isConsumer(consumer) {
+		return
+	}
+	if len(ks.ranges) == 0 {
+		ks.ranges = []keyRange{{start: 0, end: keyHashRange, consumer: consumer}}
+		return
+	}
+	largest := 0
+	for i, r := range ks.ranges {
+		if r.end-r.start > ks.ranges[largest].end-ks.ranges[largest].start {
+			largest = i
+		}
+	}
+	r := ks.ranges[largest]
+	if r.end-r.start < 2 {
+		// more consumers than hashes, it stays idle
+		logger.Warnf("no key range left for consumer %v", consumer)
+		return
+	}
+	mid := r.start + (r.end-r.start)/2
+	ks.ranges[largest].end = mid
+	ks.ranges = append(ks.ranges, keyRange{})
+	copy(ks.ranges[largest+2:], ks.ranges[largest+1:])
+	ks.ranges[largest+1] = keyRange{start: mid, end: r.end, consumer: consumer}
+	ks.moved[consumer] = append(ks.moved[consumer], movedRange{start: mid, end: r.end, until: dispatched})
+	ks.reroute()
+}
+
+// leave hands the ranges of consumer to its neighbours. If nobody is left to
+// take them, nothing is held any more and the lowest msid which was is
+// returned, 0 if none.
+func (ks *keyShared) leave(consumer string, dispatched uint64) uint64 {
+	delete(ks.moved, consumer)
+	if !ks.isConsumer(consumer) {
+		return 0
+	}
+	var ranges []keyRange
+	for i, r := range ks.ranges {
+		if r.consumer == consumer {
+			heir := ""
+			if len(ranges) > 0 {
+				heir = ranges[len(ranges)-1].consumer
+			} else {
+				for _, next := range ks.ranges[i+1:] {
+					if next.consumer != consumer {
+						heir = next.consumer
+						break
+					}
+				}
+			}
+			if heir == "" {
+				continue
+			}
+			r.consumer = heir
+			ks.moved[heir] = append(ks.moved[heir], movedRange{start: r.start, end: r.end, until: dispatched})
+		}
+		if len(ranges) > 0 && ranges[len(ranges)-1].consumer == r.consumer {
+			ranges[len(ranges)-1].end = r.end
+		} else {
+			ranges = append(ranges, r)
+		}
+	}
+	ks.ranges = ranges
+	if len(ks.ranges) == 0 {
+		floor := ks.floor()
+		ks.pending = make(map[string][]*msg.MsgData)
+		ks.overflow = make(map[string][]heldRef)
+		return floor
+	}
+	ks.reroute()
+	return 0
+}
+
+// reroute moves the held messages to the current owners of their keys, in
+// msid order so the order of each key is kept.
+func (ks *keyShared) reroute() {
+	var held []heldRef
+	msgs := make(map[uint64]*msg.MsgData)
+	for _, ms := range ks.pending {
+		for _, m := range ms {
+			held = append(held, heldRef{msid: m.Msid, key: m.Key})
+			msgs[m.Msid] = m
+		}
+	}
+	for _, refs := range ks.overflow {
+		held = append(held, refs...)
+	}
+	sort.Slice(held, func(i, j int) bool { return held[i].msid < held[j].msid })
+	ks.pending = make(map[string][]*msg.MsgData)
+	ks.overflow = make(map[string][]heldRef)
+	for _, ref := range held {
+		owner, _ := ks.owner(ref.key)
+		if m, ok := msgs[ref.msid]; ok {
+			ks.hold(owner, m)
+		} else {
+			ks.overflow[owner] = append(ks.overflow[owner], ref)
+		}
+	}
+}
+
+// hold keeps m for consumer, only its msid once too many are held.
+func (ks *keyShared) hold(consumer string, m *msg.MsgData) {
+	refs := ks.overflow[consumer]
+	if len(refs) == 0 && len(ks.pending[consumer]) < keySharedMaxPending {
+		ks.pending[consumer] = append(ks.pending[consumer], m)
+		return
+	}
+	i := sort.Search(len(refs), func(i int) bool { return refs[i].msid >= m.Msid })
+	refs = append(refs, heldRef{})
+	copy(refs[i+1:], refs[i:])
+	refs[i] = heldRef{msid: m.Msid, key: m.Key}
+	ks.overflow[consumer] = refs
+}
+
+// waits tells if the key hash h of consumer waits for the acks of a previous
+// owner, the ranges acked meanwhile are forgotten.
+func (ks *keyShared) waits(consumer string, h int, acked uint64) bool {
+	ranges, ok := ks.moved[consumer]
+	if !ok {
+		return false
+	}
+	waits := false
+	kept := ranges[:0]
+	for _, r := range ranges {
+		if acked >= r.until {
+			continue
+		}
+		kept = append(kept, r)
+		if h >= r.start && h < r.end {
+			waits = true
+		}
+	}
+	if len(kept) == 0 {
+		delete(ks.moved, consumer)
+	} else {
+		ks.moved[consumer] = kept
+	}
+	return waits
+}
+
+// ready returns the index of the oldest message held for consumer which it
+// may have now, -1 if none.
+func (ks *keyShared) ready(consumer string, acked uint64) int {
+	for i, m := range ks.pending[consumer] {
+		if !ks.waits(consumer, keyHash(m.Key), acked) {
+			return i
+		}
+	}
+	return -1
+}
+
+// next pops the oldest message held for consumer which it may have now.
+func (ks *keyShared) next(consumer string, acked uint64) *msg.MsgData {
+	i := ks.ready(consumer, acked)
+	if i < 0 {
+		return nil
+	}
+	ms := ks.pending[consumer]
+	m := ms[i]
+	ks.pending[consumer] = append(ms[:i], ms[i+1:]...)
+	return m
+}
+
+// refill reads back with load the oldest messages of consumer held by msid
+// only, as long as there is room. A message which cannot be read any more is
+// dropped, like a failed redelivery.
+func (ks *keyShared) refill(consumer string, load func(msid uint64) (*msg.MsgData, error)) {
+	refs := ks.overflow[consumer]
+	for n := 0; n < keySharedRefillMsgs && len(refs) > 0 && len(ks.pending[consumer]) < keySharedMaxPending; n++ {
+		m, err := load(refs[0].msid)
+		if err != nil {
+			logger.Errorf("load held message %v failed: %v", refs[0].msid, err)
+		} else {
+			ks.pending[consumer] = append(ks.pending[consumer], m)
+		}
+		refs = refs[1:]
+	}
+	if len(refs) == 0 {
+		delete(ks.overflow, consumer)
+	} else {
+		ks.overflow[consumer] = refs
+	}
+}
+
+// dispatch tells if consumer, which read m from the cursor, gets it now.
+// Otherwise m is held for its owner.
+func (ks *keyShared) dispatch(consumer string, m *msg.MsgData, acked uint64) bool {
+	if m.Key == "" {
+		return true
+	}
+	owner, ok := ks.owner(m.Key)
+	if !ok {
+		return true
+	}
+	// the messages held for the owner go first unless they wait, their keys
+	// are not the one of m then
+	if owner == consumer && len(ks.overflow[owner]) == 0 && ks.ready(owner, acked) < 0 && !ks.waits(owner, keyHash(m.Key), acked) {
+		return true
+	}
+	ks.hold(owner, m)
+	return false
+}
+
+// floor returns the lowest msid held, 0 if nothing is.
+func (ks *keyShared) floor() uint64 {
+	var floor uint64
+	for _, ms := range ks.pending {
+		for _, m := range ms {
+			if floor == 0 || m.Msid < floor {
+				floor = m.Msid
+			}
+		}
+	}
+	for _, refs := range ks.overflow {
+		if len(refs) > 0 && (floor == 0 || refs[0].msid < floor) {
+			floor = refs[0].msid
+		}
+	}
+	return floor
+}
+
+// nextHeld pops the oldest message held for consumer, see keyShared.next.
+// The messages held by msid only are read back with load.
+func (sub *subcription) nextHeld(consumer string, load func(msid uint64) (*msg.MsgData, error)) *msg.MsgData {
+	m := sub.keyShared.next(consumer, sub.Data.AckOffset)
+	if m == nil && len(sub.keyShared.overflow[consumer]) > 0 {
+		sub.keyShared.refill(consumer, load)
+		m = sub.keyShared.next(consumer, sub.Data.AckOffset)
+	}
+	if m != nil {
+		sub.Data.HeldFloor = sub.keyShared.floor()
+	}
+	return m
+}
+
+// dispatchKeyShared tells if consumer gets m now, see keyShared.dispatch.
+// The cursor moves on past the held messages, HeldFloor is where a reloaded
+// subscription reads again from.
+func (sub *subcription) dispatchKeyShared(consumer string, m *msg.MsgData) bool {
+	now := sub.keyShared.dispatch(consumer, m, sub.Data.AckOffset)
+	if !now {
+		sub.Data.HeldFloor = sub.keyShared.floor()
+	}
+	return now
+}
+
+func (s *Server) joinKeyShared(sub *subcription, consumer string) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if sub.keyShared == nil {
+		sub.keyShared = newKeyShared()
+	}
+	sub.keyShared.join(consumer, dispatched(sub))
+}
+
+// leaveKeyShared removes consumer from the subscription, what was held for
+// it is read again from the cursor if it was the last one.
+func (s *Server) leaveKeyShared(sub *subcription, consumer string) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	delete(sub.Data.Subers, consumer)
+	delete(sub.clients, consumer)
+	if sub.keyShared == nil {
+		return
+	}
+	left := sub.keyShared.leave(consumer, dispatched(sub))
+	sub.Data.HeldFloor = sub.keyShared.floor()
+	if left != 0 && left < sub.Data.PushOffset {
+		sub.Data.PushOffset = left
+	}
+	if err := s.PutSubcription(sub); err != nil {
+		logger.Errorf("PutSubcription failed: %v", err)
+	}
+}
+
+// dispatched returns the last msid read from the cursor of sub.
+func dispatched(sub *subcription) uint64 {
+	if sub.Data.PushOffset == 0 {
+		return 0
+	}
+	return sub.Data.PushOffset - 1
+}
@@ -0,0 +1,170 @@
+package server
+
+import (
+	"MxcMQ-Server/msg"
+	rc "MxcMQ-Server/registrationCenter"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyShared(t *testing.T) {
+	ks := newKeyShared()
+	ks.join("a", 0)
+	ks.join("b", 5)
+	assert.Equal(t, []keyRange{{0, keyHashRange / 2, "a"}, {keyHashRange / 2, keyHashRange, "b"}}, ks.ranges)
+
+	keyOf := func(consumer string) string {
+		for i := 0; ; i++ {
+			if owner, _ := ks.owner(fmt.Sprint(i)); owner == consumer {
+				return fmt.Sprint(i)
+			}
+		}
+	}
+	ka, kb := keyOf("a"), keyOf("b")
+
+	assert.True(t, ks.dispatch("a", &msg.MsgData{Msid: 6, Key: ka}, 0))
+	// a read it first, b waits for everything before it joined to be acked
+	assert.False(t, ks.dispatch("a", &msg.MsgData{Msid: 7, Key: kb}, 0))
+	assert.Nil(t, ks.next("b", 4))
+	// without key, whoever pulls, even while its keys wait
+	assert.True(t, ks.dispatch("b", &msg.MsgData{Msid: 8}, 4))
+	assert.Equal(t, uint64(7), ks.next("b", 5).Msid)
+
+	// c takes half of the range of a over, only those keys wait
+	ks.join("c", 9)
+	kc := keyOf("c")
+	assert.True(t, ks.dispatch("b", &msg.MsgData{Msid: 10, Key: kb}, 5))
+	assert.True(t, ks.dispatch("a", &msg.MsgData{Msid: 11, Key: keyOf("a")}, 5))
+	assert.False(t, ks.dispatch("c", &msg.MsgData{Msid: 12, Key: kc}, 5))
+	assert.Nil(t, ks.next("c", 8))
+	assert.Equal(t, uint64(12), ks.next("c", 9).Msid)
+	assert.Equal(t, uint64(0), ks.leave("c", 12))
+
+	// the keys of b go back to a once b left
+	ks.dispatch("a", &msg.MsgData{Msid: 13, Key: kb}, 12)
+	assert.Equal(t, uint64(0), ks.leave("b", 13))
+	assert.Equal(t, []keyRange{{0, keyHashRange, "a"}}, ks.ranges)
+	assert.Nil(t, ks.next("a", 12))
+	assert.Equal(t, uint64(13), ks.next("a", 13).Msid)
+
+	// nobody is left to take what is held, it is read again from there
+	ks.join("b", 13)
+	ks.dispatch("a", &msg.MsgData{Msid: 14, Key: kb}, 13)
+	assert.Equal(t, uint64(0), ks.leave("a", 14))
+	assert.Equal(t, uint64(14), ks.leave("b", 14))
+	assert.Empty(t, ks.ranges)
+}
+
+func TestKeySharedOverflow(t *testing.T) {
+	ks := newKeyShared()
+	ks.join("a", 0)
+	ks.join("b", 0)
+	kb := ""
+	for i := 0; kb == ""; i++ {
+		if owner, _ := ks.owner(fmt.Sprint(i)); owner == "b" {
+			kb = fmt.Sprint(i)
+		}
+	}
+
+	// past the limit only the msids are held, a redelivery keeps them sorted
+	n := uint64(keySharedMaxPending + 5)
+	for i := uint64(1); i <= n; i++ {
+		if i != n-2 {
+			assert.False(t, ks.dispatch("a", &msg.MsgData{Msid: i, Key: kb}, 0))
+		}
+	}
+	assert.False(t, ks.dispatch("a", &msg.MsgData{Msid: n - 2, Key: kb}, 0))
+	assert.Len(t, ks.pending["b"], keySharedMaxPending)
+	assert.Equal(t, []heldRef{{n - 4, kb}, {n - 3, kb}, {n - 2, kb}, {n - 1, kb}, {n, kb}}, ks.overflow["b"])
+	assert.Equal(t, uint64(1), ks.floor())
+
+	// held by msid, they stay behind the others of b
+	for i := uint64(1); i <= n-5; i++ {
+		assert.Equal(t, i, ks.next("b", 0).Msid)
+	}
+	assert.Nil(t, ks.next("b", 0))
+	assert.Equal(t, n-4, ks.floor())
+
+	load := func(msid uint64) (*msg.MsgData, error) {
+		if msid == n-3 {
+			return nil, errNoValue
+		}
+		return &msg.MsgData{Msid: msid, Key: kb}, nil
+	}
+	ks.refill("b", load)
+	assert.Empty(t, ks.overflow["b"])
+	var got []uint64
+	for m := ks.next("b", 0); m != nil; m = ks.next("b", 0) {
+		got = append(got, m.Msid)
+	}
+	// one is gone from the store
+	assert.Equal(t, []uint64{n - 4, n - 2, n - 1, n}, got)
+	assert.Equal(t, uint64(0), ks.floor())
+}
+
+// keyOwnedBy returns a key of sub dispatched to consumer.
+func keyOwnedBy(sub *subcription, consumer string) string {
+	for i := 0; ; i++ {
+		if owner, _ := sub.keyShared.owner(fmt.Sprint(i)); owner == consumer {
+			return fmt.Sprint(i)
+		}
+	}
+}
+
+func TestKeySharedHeldFloor(t *testing.T) {
+	s, p := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1})
+	sub := addSub(t, s, "t", "s1", Key_Shared)
+	ca := addConsumer(s, sub, "a")
+	cb := addConsumer(s, sub, "b")
+	assert.Nil(t, s.store.Append("t", 1,
+		&msg.MsgData{Msid: 1, Key: keyOwnedBy(sub, "b")},
+		&msg.MsgData{Msid: 2, Key: keyOwnedBy(sub, "a")}))
+	p.pNode.Mnum = 2
+
+	// 1 is held for b, the cursor goes on for a
+	pull(t, s, sub, "a", 1)
+	assert.Equal(t, []uint64{2}, ca.msids())
+	assert.Equal(t, uint64(3), sub.Data.PushOffset)
+	assert.Equal(t, uint64(1), sub.Data.HeldFloor)
+
+	// a reloaded subscription reads the held message again
+	assert.Nil(t, s.PutSubcription(sub))
+	loaded, err := s.GetSubcription(&sub.Data.Meta)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), loaded.Data.PushOffset)
+
+	pull(t, s, sub, "b", 1)
+	assert.Equal(t, []uint64{1}, cb.msids())
+	assert.Equal(t, uint64(0), sub.Data.HeldFloor)
+}
+
+func TestKeySharedSlowConsumer(t *testing.T) {
+	s, p := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1})
+	sub := addSub(t, s, "t", "s1", Key_Shared)
+	ca := addConsumer(s, sub, "a")
+	cb := addConsumer(s, sub, "b")
+	kb := keyOwnedBy(sub, "b")
+	n := uint64(keySharedMaxPending + 10)
+	for i := uint64(1); i <= n; i++ {
+		assert.Nil(t, s.store.Append("t", 1, &msg.MsgData{Msid: i, Key: kb}))
+	}
+	assert.Nil(t, s.store.Append("t", 1, &msg.MsgData{Msid: n + 1, Key: keyOwnedBy(sub, "a")}))
+	p.pNode.Mnum = n + 1
+
+	// b does not pull, a still gets its message past the backlog of b
+	pull(t, s, sub, "a", 1)
+	assert.Equal(t, []uint64{n + 1}, ca.msids())
+	assert.Equal(t, n+2, sub.Data.PushOffset)
+	assert.Equal(t, uint64(1), sub.Data.HeldFloor)
+
+	// b gets all of it in order, the tail read again from the store
+	pull(t, s, sub, "b", int(n))
+	want := make([]uint64, 0, n)
+	for i := uint64(1); i <= n; i++ {
+		want = append(want, i)
+	}
+	assert.Equal(t, want, cb.msids())
+	assert.Equal(t, uint64(0), sub.Data.HeldFloor)
+}
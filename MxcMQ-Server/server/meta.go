@@ -0,0 +1,21 @@
+package server
+
+import rc "MxcMQ-Server/registrationCenter"
+
+// metaStore holds the topic, partition and subscription nodes of the
+// cluster, it is zk outside of tests.
+type metaStore interface {
+	RegisterTnode(tnode *rc.TopicNode) error
+	RegisterPnode(pnode *rc.PartitionNode) error
+	RegisterSnode(snode *rc.SubcriptionNode) error
+	GetTopic(topic string) (*rc.TopicNode, error)
+	GetPartition(topic string, partition int) (*rc.PartitionNode, error)
+	GetSub(snode *rc.SubcriptionNode) (*rc.SubcriptionNode, error)
+	GetSubs(topic string, partition int) ([]*rc.SubcriptionNode, error)
+	IsTopicExists(topic string) (bool, error)
+	IsPartitionExists(topic string, partition int) (bool, error)
+	IsSubcriptionExist(snode *rc.SubcriptionNode) (bool, error)
+	UpdateTopic(tNode *rc.TopicNode) error
+	UpdatePartition(pNode *rc.PartitionNode) error
+	DeleteTopic(topic string) error
+}
@@ -0,0 +1,225 @@
+package server
+
+import (
+	pb "MxcMQ-Server/proto"
+	rc "MxcMQ-Server/registrationCenter"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"google.golang.org/grpc"
+)
+
+// memMeta keeps the nodes in memory the way zk does, every Get returns a
+// copy and updates check the version.
+type memMeta struct {
+	mu    sync.Mutex
+	nodes map[string][]byte
+	// failUpdate is returned by the next UpdatePartition
+	failUpdate error
+}
+
+func newMemMeta() *memMeta {
+	return &memMeta{nodes: make(map[string][]byte)}
+}
+
+func topicPath(topic string) string { return topic }
+
+func partitionPath(topic string, partition int) string {
+	return fmt.Sprintf("%v/p%v", topic, partition)
+}
+
+func subPath(snode *rc.SubcriptionNode) string {
+	return fmt.Sprintf("%v/p%v/subscription/%v", snode.TopicName, snode.Partition, snode.Name)
+}
+
+func (m *memMeta) create(path string, v interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.nodes[path]; ok {
+		return zk.ErrNodeExists
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	m.nodes[path] = data
+	return nil
+}
+
+func (m *memMeta) get(path string, v interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.nodes[path]
+	if !ok {
+		return zk.ErrNoNode
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (m *memMeta) set(path string, v interface{}, version *int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.nodes[path]
+	if !ok {
+		return zk.ErrNoNode
+	}
+	var stored struct{ Version int32 }
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	if stored.Version != *version {
+		return zk.ErrBadVersion
+	}
+	*version++
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	m.nodes[path] = data
+	return nil
+}
+
+func (m *memMeta) exists(path string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.nodes[path]
+	return ok
+}
+
+func (m *memMeta) RegisterTnode(tnode *rc.TopicNode) error {
+	return m.create(topicPath(tnode.Name), tnode)
+}
+
+func (m *memMeta) RegisterPnode(pnode *rc.PartitionNode) error {
+	return m.create(partitionPath(pnode.TopicName, pnode.ID), pnode)
+}
+
+func (m *memMeta) RegisterSnode(snode *rc.SubcriptionNode) error {
+	return m.create(subPath(snode), snode)
+}
+
+func (m *memMeta) GetTopic(topic string) (*rc.TopicNode, error) {
+	tNode := &rc.TopicNode{}
+	if err := m.get(topicPath(topic), tNode); err != nil {
+		return nil, err
+	}
+	return tNode, nil
+}
+
+func (m *memMeta) GetPartition(topic string, partition int) (*rc.PartitionNode, error) {
+	pNode := &rc.PartitionNode{}
+	if err := m.get(partitionPath(topic, partition), pNode); err != nil {
+		return nil, err
+	}
+	return pNode, nil
+}
+
+func (m *memMeta) GetSub(snode *rc.SubcriptionNode) (*rc.SubcriptionNode, error) {
+	sNode := &rc.SubcriptionNode{}
+	if err := m.get(subPath(snode), sNode); err != nil {
+		return nil, err
+	}
+	return sNode, nil
+}
+
+func (m *memMeta) GetSubs(topic string, partition int) ([]*rc.SubcriptionNode, error) {
+	prefix := partitionPath(topic, partition) + "/subscription/"
+	m.mu.Lock()
+	var paths []string
+	for path := range m.nodes {
+		if len(path) > len(prefix) && path[:len(prefix)] == prefix {
+			paths = append(paths, path)
+		}
+	}
+	m.mu.Unlock()
+	sort.Strings(paths)
+
+	var subs []*rc.SubcriptionNode
+	for _, path := range paths {
+		sNode := &rc.SubcriptionNode{}
+		if err := m.get(path, sNode); err != nil {
+			return nil, err
+		}
+		subs = append(subs, sNode)
+	}
+	return subs, nil
+}
+
+func (m *memMeta) IsTopicExists(topic string) (bool, error) {
+	return m.exists(topicPath(topic)), nil
+}
+
+func (m *memMeta) IsPartitionExists(topic string, partition int) (bool, error) {
+	return m.exists(partitionPath(topic, partition)), nil
+}
+
+func (m *memMeta) IsSubcriptionExist(snode *rc.SubcriptionNode) (bool, error) {
+	return m.exists(subPath(snode)), nil
+}
+
+func (m *memMeta) UpdateTopic(tNode *rc.TopicNode) error {
+	return m.set(topicPath(tNode.Name), tNode, &tNode.Version)
+}
+
+func (m *memMeta) UpdatePartition(pNode *rc.PartitionNode) error {
+	m.mu.Lock()
+	err := m.failUpdate
+	m.failUpdate = nil
+	m.mu.Unlock()
+	if err != nil {
+		pNode.Version++
+		return err
+	}
+	return m.set(partitionPath(pNode.TopicName, pNode.ID), pNode, &pNode.Version)
+}
+
+func (m *memMeta) DeleteTopic(topic string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.nodes[topicPath(topic)]; !ok {
+		return zk.ErrNoNode
+	}
+	prefix := topicPath(topic) + "/"
+	for path := range m.nodes {
+		if path == topicPath(topic) || strings.HasPrefix(path, prefix) {
+			delete(m.nodes, path)
+		}
+	}
+	return nil
+}
+
+// recordConn is the connection of a subscriber which takes every message
+// pushed to it.
+type recordConn struct {
+	mu   sync.Mutex
+	msgs []*pb.MsgArgs
+}
+
+func (c *recordConn) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	if m, ok := args.(*pb.MsgArgs); ok {
+		c.mu.Lock()
+		c.msgs = append(c.msgs, m)
+		c.mu.Unlock()
+	}
+	return nil
+}
+
+func (c *recordConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, errors.New("no streams")
+}
+
+func (c *recordConn) msids() []uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var msids []uint64
+	for _, m := range c.msgs {
+		msids = append(msids, m.Msid)
+	}
+	return msids
+}
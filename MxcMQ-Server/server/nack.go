@@ -0,0 +1,102 @@
+package server
+
+import (
+	"MxcMQ-Server/logger"
+	"MxcMQ-Server/msg"
+	pb "MxcMQ-Server/proto"
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// backoff of the subscriptions which did not set one
+const (
+	defaultNackMinDelay = 1000  // millisecond
+	defaultNackMaxDelay = 60000 // millisecond
+)
+
+const nackReason = "negative acknowledgement"
+
+type NackBackoff struct {
+	MinDelay int64 // millisecond
+	MaxDelay int64 // millisecond
+}
+
+// delay returns how long to wait before the nth redelivery of a message.
+func (b NackBackoff) delay(n int) int64 {
+	min, max := b.MinDelay, b.MaxDelay
+	if min <= 0 {
+		min = defaultNackMinDelay
+	}
+	if max <= 0 {
+		max = defaultNackMaxDelay
+	}
+	if max < min {
+		max = min
+	}
+	d := min
+	for i := 1; i < n && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
+
+// nack schedules the redelivery of msid after the backoff of sub. It tells
+// if msid is to be dead lettered instead.
+func (sub *subcription) nack(msid uint64, now int64) (int, bool) {
+	delete(sub.inflight, msid)
+	count, dead := sub.failed(msid, nackReason)
+	if !dead {
+		heap.Push(&sub.nacked, delayedMsg{at: now + sub.Data.NackBackoff.delay(count), msid: msid})
+	}
+	return count, dead
+}
+
+// promoteNacked queues the nacked messages whose backoff is over for
+// redelivery.
+func (sub *subcription) promoteNacked(now int64) {
+	for len(sub.nacked) > 0 && sub.nacked[0].at <= now {
+		sub.redeliver(heap.Pop(&sub.nacked).(delayedMsg).msid)
+	}
+}
+
+// Nack redelivers the given messages to the subscription after a backoff,
+// the cursor goes on with later messages meanwhile. A request naming a
+// message not in flight is refused as a whole.
+func (s *Server) Nack(ctx context.Context, args *pb.NackArgs) (*pb.NackReply, error) {
+	logger.Infof("Receive Nack rq from %v", args)
+	reply := &pb.NackReply{}
+	skey := fmt.Sprintf(subcriptionKey, args.Topic, args.Partition, args.Subscription)
+	exSub, ok := s.Sl.Subs[skey]
+	if !ok {
+		logger.Errorf("there is no this subcription %v", skey)
+		return reply, errors.New("404")
+	}
+
+	now := time.Now().UnixMilli()
+	dead := make(map[uint64]int)
+	exSub.mu.Lock()
+	// only what was delivered and is not acked yet comes back
+	for _, msid := range args.Msids {
+		if _, ok := exSub.inflight[msid]; !ok || exSub.isAcked(msid) {
+			exSub.mu.Unlock()
+			logger.Warnf("nack of %v not in flight in %v", msid, skey)
+			return reply, fmt.Errorf("message %v is not in flight", msid)
+		}
+	}
+	for _, msid := range args.Msids {
+		if count, ok := exSub.nack(msid, now); ok {
+			dead[msid] = count
+		}
+	}
+	exSub.mu.Unlock()
+
+	pua := &msg.PullArg{Topic: args.Topic, Partition: int(args.Partition), Subname: args.Subscription}
+	s.deadLetterMsids(pua, exSub, dead, nackReason)
+	return reply, nil
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"MxcMQ-Server/msg"
+	pb "MxcMQ-Server/proto"
+	rc "MxcMQ-Server/registrationCenter"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNackBackoff(t *testing.T) {
+	b := NackBackoff{MinDelay: 100, MaxDelay: 1000}
+	assert.Equal(t, int64(100), b.delay(1))
+	assert.Equal(t, int64(200), b.delay(2))
+	assert.Equal(t, int64(800), b.delay(4))
+	assert.Equal(t, int64(1000), b.delay(5))
+	assert.Equal(t, int64(1000), b.delay(50))
+	assert.Equal(t, int64(defaultNackMinDelay), NackBackoff{}.delay(1))
+	assert.Equal(t, int64(defaultNackMaxDelay), NackBackoff{}.delay(50))
+}
+
+func TestNack(t *testing.T) {
+	sub := NewSubcription()
+	sub.Data.NackBackoff = NackBackoff{MinDelay: 100, MaxDelay: 1000}
+	sub.Data.DeadLetter.MaxDeliveries = 3
+
+	sub.nack(5, 0)
+	sub.nack(2, 50)
+	sub.promoteNacked(120)
+	// only 5 is due, later messages are not held back
+	msid, ok := sub.nextRedelivery()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), msid)
+	_, ok = sub.nextRedelivery()
+	assert.False(t, ok)
+
+	count, dead := sub.nack(5, 200)
+	assert.Equal(t, 2, count)
+	assert.False(t, dead)
+	sub.promoteNacked(399)
+	msid, _ = sub.nextRedelivery()
+	assert.Equal(t, uint64(2), msid)
+	_, ok = sub.nextRedelivery()
+	assert.False(t, ok)
+	sub.promoteNacked(400)
+	msid, _ = sub.nextRedelivery()
+	assert.Equal(t, uint64(5), msid)
+
+	_, dead = sub.nack(5, 400)
+	assert.True(t, dead)
+	assert.Equal(t, 0, len(sub.nacked))
+}
+
+func TestNackInFlight(t *testing.T) {
+	s, p := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1})
+	p.reaperOnce.Do(func() {})
+	for i := uint64(1); i <= 3; i++ {
+		assert.Nil(t, s.store.Append("t", 1, &msg.MsgData{Msid: i, Payload: []byte("m")}))
+	}
+	p.pNode.Mnum = 3
+	sub := addSub(t, s, "t", "s1", Shared)
+	addConsumer(s, sub, "c1")
+	nack := func(msids ...uint64) error {
+		_, err := s.Nack(context.Background(), &pb.NackArgs{Topic: "t", Partition: 1, Subscription: "s1", Msids: msids})
+		return err
+	}
+
+	// not delivered yet
+	assert.NotNil(t, nack(1))
+	pull(t, s, sub, "c1", 2)
+	assert.NotNil(t, nack(1, 3))
+	assert.Equal(t, 0, sub.redeliveryCount(1))
+
+	// acked already
+	_, err := s.MsgAck(context.Background(), &pb.MsgAckArgs{Topic: "t", Partition: 1, Subscription: "s1", Msids: []uint64{2}})
+	assert.Nil(t, err)
+	assert.NotNil(t, nack(2))
+
+	assert.Nil(t, nack(1))
+	assert.Equal(t, 1, sub.redeliveryCount(1))
+	// out of flight until redelivered
+	assert.NotNil(t, nack(1))
+}
@@ -0,0 +1,244 @@
+package server
+
+import (
+	"MxcMQ-Server/config"
+	"MxcMQ-Server/logger"
+	"MxcMQ-Server/msg"
+	"MxcMQ-Server/persist"
+	rc "MxcMQ-Server/registrationCenter"
+	"fmt"
+)
+
+const defaultOffloadRangeMsgs = 1000
+
+// offloadedRange caches the messages of the last offloaded range read.
+type offloadedRange struct {
+	rc.OffloadRange
+	msgs []*msg.MsgData
+}
+
+func offloadObject(topic string, partition int, r rc.OffloadRange) string {
+	return fmt.Sprintf("%s/p%d/%020d-%020d", topic, partition, r.Start, r.End)
+}
+
+// objectsIn returns the objects of r holding messages in [start, end].
+func objectsIn(r rc.OffloadRange, start, end uint64) []rc.OffloadRange {
+	if start < r.Start {
+		start = r.Start
+	}
+	if end > r.End {
+		end = r.End
+	}
+	if start > end {
+		return nil
+	}
+	if r.Step == 0 {
+		return []rc.OffloadRange{{Start: r.Start, End: r.End}}
+	}
+
+	var objs []rc.OffloadRange
+	for from := r.Start + (start-r.Start)/r.Step*r.Step; from <= end; from += r.Step {
+		to := from + r.Step - 1
+		if to > r.End {
+			to = r.End
+		}
+		objs = append(objs, rc.OffloadRange{Start: from, End: to})
+	}
+	return objs
+}
+
+// addOffloaded records the object r on pNode. r extends the last range if it
+// follows it with the same size, so that pNode keeps a range per run of
+// offloads instead of one per object. Readers may hold the old slice, it is
+// never written in place.
+func addOffloaded(pNode *rc.PartitionNode, r rc.OffloadRange) {
+	ranges := pNode.Offloaded
+	n := len(ranges)
+	if n > 0 {
+		last := ranges[n-1]
+		step := last.Step
+		if step == 0 {
+			step = last.End - last.Start + 1
+		}
+		if last.End+1 == r.Start && r.End-r.Start+1 == step {
+			merged := append(make([]rc.OffloadRange, 0, n), ranges[:n-1]...)
+			pNode.Offloaded = append(merged, rc.OffloadRange{Start: last.Start, End: r.End, Step: step})
+			return
+		}
+	}
+	pNode.Offloaded = append(ranges[:n:n], r)
+}
+
+// offload moves the oldest hot messages of p to the object store, a whole
+// range at a time, while p keeps more hot messages than the topic allows.
+func (s *Server) offload(p *partitionData) error {
+	if s.objects == nil {
+		return nil
+	}
+	tNode, err := s.meta.GetTopic(p.pNode.TopicName)
+	if err != nil {
+		return err
+	}
+	maxHot := tNode.Offload.MaxHotMsgs
+	if maxHot == 0 {
+		return nil
+	}
+	rangeMsgs := config.StConf.OffloadRangeMsgs
+	if rangeMsgs == 0 {
+		rangeMsgs = defaultOffloadRangeMsgs
+	}
+
+	for {
+		p.mu.Lock()
+		topic, partition := p.pNode.TopicName, p.pNode.ID
+		first, last := p.pNode.DeleteOffset+1, p.pNode.Mnum
+		if p.pNode.OffloadOffset >= first {
+			first = p.pNode.OffloadOffset + 1
+		}
+		p.mu.Unlock()
+		// up to rangeMsgs-1 messages more than maxHot stay hot, every object
+		// is then as large as the others and the ranges merge
+		if first > last || last-first+1 < maxHot+rangeMsgs {
+			return nil
+		}
+
+		r := rc.OffloadRange{Start: first, End: first + rangeMsgs - 1}
+		msgs, err := s.store.Read(topic, partition, r.Start, r.End)
+		if err != nil {
+			return err
+		}
+		data, err := persist.EncodeRange(msgs)
+		if err != nil {
+			return err
+		}
+		if err := s.objects.Put(offloadObject(topic, partition, r), data); err != nil {
+			return err
+		}
+
+		p.mu.Lock()
+		addOffloaded(p.pNode, r)
+		p.pNode.OffloadOffset = r.End
+		err = s.meta.UpdatePartition(p.pNode)
+		p.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		logger.Infof("offload messages %v-%v of %v/p%v", r.Start, r.End, topic, partition)
+
+		// the hot copy goes only after the offload is recorded
+		if err := s.store.Delete(topic, partition, r.Start, r.End); err != nil {
+			return err
+		}
+	}
+}
+
+// readMsgs returns the messages in [start, end] of a partition, offloaded
+// ones are read from the object store.
+func (s *Server) readMsgs(topic string, partition int, start, end uint64) ([]*msg.MsgData, error) {
+	if s.objects == nil {
+		return s.store.Read(topic, partition, start, end)
+	}
+
+	var p *partitionData
+	var ranges []rc.OffloadRange
+	var offloadOffset uint64
+	if v, ok := s.partitions.Load(fmt.Sprintf(partitionKey, topic, partition)); ok {
+		p = v.(*partitionData)
+		p.mu.Lock()
+		ranges, offloadOffset = p.pNode.Offloaded, p.pNode.OffloadOffset
+		p.mu.Unlock()
+	} else {
+		// another broker owns the partition, zk tells what it offloaded
+		pNode, err := s.meta.GetPartition(topic, partition)
+		if err != nil {
+			return nil, err
+		}
+		ranges, offloadOffset = pNode.Offloaded, pNode.OffloadOffset
+	}
+	return s.readRange(p, topic, partition, ranges, offloadOffset, start, end)
+}
+
+// readRange returns the messages in [start, end] of a partition which
+// offloaded ranges up to offloadOffset, p caches the last object read if it
+// is not nil.
+func (s *Server) readRange(p *partitionData, topic string, partition int, ranges []rc.OffloadRange, offloadOffset uint64, start, end uint64) ([]*msg.MsgData, error) {
+	var msgs []*msg.MsgData
+	for _, r := range ranges {
+		for _, obj := range objectsIn(r, start, end) {
+			cold, err := s.readOffloaded(p, topic, partition, obj)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range cold {
+				if m.Msid >= start && m.Msid <= end {
+					msgs = append(msgs, m)
+				}
+			}
+		}
+	}
+	if end <= offloadOffset {
+		return msgs, nil
+	}
+
+	if start <= offloadOffset {
+		start = offloadOffset + 1
+	}
+	hot, err := s.store.Read(topic, partition, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return append(msgs, hot...), nil
+}
+
+// readOffloaded reads the object r, the last one read is cached on p if it is
+// not nil.
+func (s *Server) readOffloaded(p *partitionData, topic string, partition int, r rc.OffloadRange) ([]*msg.MsgData, error) {
+	if p != nil {
+		p.coldMu.Lock()
+		defer p.coldMu.Unlock()
+		if p.cold != nil && p.cold.OffloadRange == r {
+			return p.cold.msgs, nil
+		}
+	}
+
+	data, err := s.objects.Get(offloadObject(topic, partition, r))
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := persist.DecodeRange(data)
+	if err != nil {
+		return nil, err
+	}
+	if p != nil {
+		p.cold = &offloadedRange{OffloadRange: r, msgs: msgs}
+	}
+	return msgs, nil
+}
+
+// trimOffloaded forgets the objects of p wholly under end and returns them,
+// p.mu must be held.
+func trimOffloaded(p *partitionData, end uint64) []rc.OffloadRange {
+	var kept, trimmed []rc.OffloadRange
+	for _, r := range p.pNode.Offloaded {
+		for _, obj := range objectsIn(r, r.Start, end) {
+			if obj.End > end {
+				break
+			}
+			trimmed = append(trimmed, obj)
+			r.Start = obj.End + 1
+		}
+		if r.Start <= r.End {
+			kept = append(kept, r)
+		}
+	}
+	p.pNode.Offloaded = kept
+	return trimmed
+}
+
+func (s *Server) deleteOffloaded(topic string, partition int, ranges []rc.OffloadRange) {
+	for _, r := range ranges {
+		if err := s.objects.Delete(offloadObject(topic, partition, r)); err != nil {
+			logger.Warnf("delete offloaded %v failed: %v", offloadObject(topic, partition, r), err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"MxcMQ-Server/config"
+	"MxcMQ-Server/msg"
+	"MxcMQ-Server/persist"
+	rc "MxcMQ-Server/registrationCenter"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffload(t *testing.T) {
+	config.StConf.OffloadRangeMsgs = 3
+	defer func() { config.StConf.OffloadRangeMsgs = 0 }()
+
+	s, p := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1, Offload: rc.OffloadPolicy{MaxHotMsgs: 2}})
+	objects, err := persist.NewFileObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	s.objects = objects
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, s.commitMsgs(p, &msg.MsgData{Payload: []byte("m")}))
+	}
+
+	// 7-10 stay hot, a third range would leave fewer than 2
+	assert.Nil(t, s.offload(p))
+	assert.Equal(t, uint64(6), p.pNode.OffloadOffset)
+	assert.Equal(t, []rc.OffloadRange{{Start: 1, End: 6, Step: 3}}, p.pNode.Offloaded)
+	hot, err := s.store.Read("t", 1, 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{7, 8, 9, 10}, msids(hot))
+
+	msgs, err := s.readMsgs("t", 1, 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, msids(msgs))
+
+	sub := addSub(t, s, "t", "s1", Exclusive)
+	conn := addConsumer(s, sub, "c1")
+	pull(t, s, sub, "c1", 5)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, conn.msids())
+
+	// a partition of another broker is read through zk
+	s.partitions.Delete(fmt.Sprintf(partitionKey, "t", 1))
+	msgs, err = s.readMsgs("t", 1, 2, 8)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{2, 3, 4, 5, 6, 7, 8}, msids(msgs))
+
+	// deleting goes object by object
+	trimmed := trimOffloaded(p, 4)
+	assert.Equal(t, []rc.OffloadRange{{Start: 1, End: 3}}, trimmed)
+	assert.Equal(t, []rc.OffloadRange{{Start: 4, End: 6, Step: 3}}, p.pNode.Offloaded)
+}
+
+func TestAddOffloaded(t *testing.T) {
+	pNode := &rc.PartitionNode{}
+	addOffloaded(pNode, rc.OffloadRange{Start: 1, End: 2})
+	// offloaded before the range size changed
+	addOffloaded(pNode, rc.OffloadRange{Start: 3, End: 5})
+	held := pNode.Offloaded
+	addOffloaded(pNode, rc.OffloadRange{Start: 6, End: 8})
+	addOffloaded(pNode, rc.OffloadRange{Start: 9, End: 11})
+	assert.Equal(t, []rc.OffloadRange{{Start: 1, End: 2}, {Start: 3, End: 11, Step: 3}}, pNode.Offloaded)
+	assert.Equal(t, []rc.OffloadRange{{Start: 1, End: 2}, {Start: 3, End: 5}}, held)
+
+	assert.Equal(t, []rc.OffloadRange{{Start: 6, End: 8}, {Start: 9, End: 11}}, objectsIn(pNode.Offloaded[1], 7, 20))
+}
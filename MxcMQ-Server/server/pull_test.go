@@ -0,0 +1,118 @@
+package server
+
+import (
+	"MxcMQ-Server/msg"
+	"MxcMQ-Server/persist"
+	pb "MxcMQ-Server/proto"
+	rc "MxcMQ-Server/registrationCenter"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newPullServer returns a server owning partition 1 of tNode, with zk, etcd
+// and the message store in memory.
+func newPullServer(t *testing.T, tNode *rc.TopicNode) (*Server, *partitionData) {
+	meta := newMemMeta()
+	assert.Nil(t, meta.RegisterTnode(tNode))
+	pNode := &rc.PartitionNode{TopicName: tNode.Name, ID: 1}
+	assert.Nil(t, meta.RegisterPnode(pNode))
+
+	s := &Server{
+		store:   persist.NewMemoryStore(),
+		meta:    meta,
+		cursors: persist.NewMemoryObjectStore(),
+		Sl:      NewSublist(),
+		cache:   newMsgCache(1 << 20),
+	}
+	p := &partitionData{pNode: pNode}
+	s.partitions.Store(fmt.Sprintf(partitionKey, tNode.Name, 1), p)
+	return s, p
+}
+
+// addSub adds the subscription name of partition 1 of topic.
+func addSub(t *testing.T, s *Server, topic, name string, subtype int) *subcription {
+	sNode := &rc.SubcriptionNode{Name: name, TopicName: topic, Partition: 1, Subtype: subtype}
+	assert.Nil(t, s.meta.RegisterSnode(sNode))
+	sub := NewSubcription()
+	sub.Data.Meta = *sNode
+	sub.Data.PushOffset = 1
+	s.Sl.Subs[fmt.Sprintf(subcriptionKey, topic, 1, name)] = sub
+	return sub
+}
+
+// addConsumer connects consumer to sub, it gets the messages pushed to it.
+func addConsumer(s *Server, sub *subcription, consumer string) *recordConn {
+	conn := &recordConn{}
+	sub.mu.Lock()
+	sub.Data.Subers[consumer] = consumer
+	sub.clients[consumer] = conn
+	sub.mu.Unlock()
+	if sub.Data.Meta.Subtype == Key_Shared {
+		s.joinKeyShared(sub, consumer)
+	}
+	return conn
+}
+
+// pull asks ProcessPull for n messages of sub as consumer.
+func pull(t *testing.T, s *Server, sub *subcription, consumer string, n int) {
+	_, err := s.ProcessPull(context.Background(), &pb.PullArgs{
+		Name:         consumer,
+		Topic:        sub.Data.Meta.TopicName,
+		Partition:    1,
+		Subscription: sub.Data.Meta.Name,
+		BufSize:      int32(n),
+		Timeout:      1,
+	})
+	assert.Nil(t, err)
+}
+
+func TestPullSkipsCorrupt(t *testing.T) {
+	s, p := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1})
+	payload := []byte("payload")
+	msgs := []*msg.MsgData{
+		{Msid: 1, Payload: payload, Crc: msg.Checksum(payload), HasCrc: true},
+		{Msid: 2, Payload: payload, Crc: 0, HasCrc: true},
+		// stored before checksums
+		{Msid: 3, Payload: payload},
+	}
+	assert.Nil(t, s.store.Append("t", 1, msgs...))
+	p.pNode.Mnum = 3
+	sub := addSub(t, s, "t", "s1", Exclusive)
+	conn := addConsumer(s, sub, "c1")
+
+	pull(t, s, sub, "c1", 3)
+	assert.Equal(t, []uint64{1, 3}, conn.msids())
+	assert.Equal(t, uint64(1), atomic.LoadUint64(&s.corruptMsgs))
+	assert.Equal(t, uint64(4), sub.Data.PushOffset)
+}
+
+func TestPullWhileAcking(t *testing.T) {
+	s, p := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1})
+	p.reaperOnce.Do(func() {})
+	for i := uint64(1); i <= 20; i++ {
+		assert.Nil(t, s.store.Append("t", 1, &msg.MsgData{Msid: i, Payload: []byte("payload")}))
+	}
+	p.pNode.Mnum = 20
+	sub := addSub(t, s, "t", "s1", Exclusive)
+	conn := addConsumer(s, sub, "c1")
+
+	// acks move the cursor state while the pull persists it
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := uint64(1); i <= 20; i++ {
+			_, err := s.MsgAck(context.Background(), &pb.MsgAckArgs{
+				Topic: "t", Partition: 1, Subscription: "s1", AckOffset: i,
+			})
+			assert.Nil(t, err)
+		}
+	}()
+	pull(t, s, sub, "c1", 20)
+	<-done
+	assert.Len(t, conn.msids(), 20)
+	assert.Equal(t, uint64(20), sub.Data.AckOffset)
+}
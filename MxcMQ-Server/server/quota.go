@@ -0,0 +1,144 @@
+package server
+
+import (
+	"MxcMQ-Server/config"
+	rc "MxcMQ-Server/registrationCenter"
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// how often a held publish looks for room again
+const quotaHoldInterval = 100 * time.Millisecond
+
+// holdTicker returns when a held publish checks its quota again, and how to
+// stop it. Tests tick by hand.
+var holdTicker = func() (<-chan time.Time, func()) {
+	ticker := time.NewTicker(quotaHoldInterval)
+	return ticker.C, ticker.Stop
+}
+
+func quotaExceeded(p *partitionData) error {
+	return status.Errorf(codes.ResourceExhausted, "quota of %v/p%v exceeded", p.pNode.TopicName, p.pNode.ID)
+}
+
+// quotaOf returns the quota of the topic of p, it is read from zk once and
+// refreshed by the reaper with the message ttl.
+func (s *Server) quotaOf(p *partitionData) (rc.QuotaPolicy, error) {
+	p.mu.Lock()
+	q := p.quota
+	p.mu.Unlock()
+	if q != nil {
+		return *q, nil
+	}
+	return s.refreshPolicies(p)
+}
+
+func (s *Server) refreshPolicies(p *partitionData) (rc.QuotaPolicy, error) {
+	tNode, err := s.meta.GetTopic(p.pNode.TopicName)
+	if err != nil {
+		return rc.QuotaPolicy{}, err
+	}
+	p.mu.Lock()
+	p.quota = &tNode.Quota
+	p.messageTTL = tNode.MessageTTL
+	p.mu.Unlock()
+	return tNode.Quota, nil
+}
+
+// checkQuota makes room for size more payload bytes in p, or fails, as the
+// quota of the topic says.
+func (s *Server) checkQuota(ctx context.Context, p *partitionData, size int64) error {
+	q, err := s.quotaOf(p)
+	if err != nil {
+		return err
+	}
+	if q.MaxBacklogMsgs == 0 && q.MaxStorageBytes == 0 {
+		return nil
+	}
+	if q.MaxStorageBytes > 0 && size > q.MaxStorageBytes {
+		// never fits
+		return quotaExceeded(p)
+	}
+	over, err := s.overQuota(p, q, size)
+	if err != nil || !over {
+		return err
+	}
+
+	switch q.Action {
+	case rc.QuotaHold:
+		timeout := config.SrvConf.QuotaHoldTimeout
+		if timeout <= 0 {
+			timeout = 2
+		}
+		timer := time.NewTimer(time.Second * time.Duration(timeout))
+		defer timer.Stop()
+		ticks, stop := holdTicker()
+		defer stop()
+		for over {
+			select {
+			case <-ticks:
+			case <-timer.C:
+				return quotaExceeded(p)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			// acks shrink the backlog, retention and gc free storage
+			if over, err = s.overQuota(p, q, size); err != nil {
+				return err
+			}
+		}
+		return nil
+	case rc.QuotaEvict:
+		return s.evict(p, q, size)
+	default:
+		return quotaExceeded(p)
+	}
+}
+
+// overQuota tells if a message of size payload bytes does not fit in p.
+func (s *Server) overQuota(p *partitionData, q rc.QuotaPolicy, size int64) (bool, error) {
+	p.mu.Lock()
+	deleteOffset, mnum, stored := p.pNode.DeleteOffset, p.pNode.Mnum, p.pNode.Size
+	p.mu.Unlock()
+
+	if q.MaxStorageBytes > 0 && stored+size > q.MaxStorageBytes {
+		return true, nil
+	}
+	// the backlog is never larger than what is retained
+	if q.MaxBacklogMsgs == 0 || mnum-deleteOffset < q.MaxBacklogMsgs {
+		return false, nil
+	}
+	floor, _, err := s.ackFloor(p)
+	if err != nil {
+		return false, err
+	}
+	if floor < deleteOffset {
+		floor = deleteOffset
+	}
+	return mnum-floor >= q.MaxBacklogMsgs, nil
+}
+
+// evict deletes the oldest messages of p until a message of size payload
+// bytes fits in the quota.
+func (s *Server) evict(p *partitionData, q rc.QuotaPolicy, size int64) error {
+	p.mu.Lock()
+	mnum := p.pNode.Mnum
+	p.mu.Unlock()
+
+	return s.deleteBefore(p, mnum, func(first, last uint64, stored int64) (uint64, int64, error) {
+		var policy rc.RetentionPolicy
+		if q.MaxBacklogMsgs == 1 || (q.MaxStorageBytes > 0 && size >= q.MaxStorageBytes) {
+			return last, stored, nil
+		}
+		if q.MaxBacklogMsgs > 0 {
+			policy.MaxMsgs = q.MaxBacklogMsgs - 1
+		}
+		if q.MaxStorageBytes > 0 {
+			policy.MaxBytes = q.MaxStorageBytes - size
+		}
+		return s.expiredTo(p, policy, first, last, stored, last)
+	})
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	rc "MxcMQ-Server/registrationCenter"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckQuota(t *testing.T) {
+	defaultHoldTicker := holdTicker
+	s := &Server{}
+	p := &partitionData{
+		pNode: &rc.PartitionNode{TopicName: "t", ID: 1, Mnum: 10, Size: 100},
+		quota: &rc.QuotaPolicy{MaxStorageBytes: 120},
+	}
+	ctx := context.Background()
+
+	assert.Nil(t, s.checkQuota(ctx, p, 20))
+	err := s.checkQuota(ctx, p, 21)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	ticks := make(chan time.Time)
+	holdTicker = func() (<-chan time.Time, func()) { return ticks, func() {} }
+	defer func() { holdTicker = defaultHoldTicker }()
+	p.quota.Action = rc.QuotaHold
+	done := make(chan error)
+	go func() { done <- s.checkQuota(ctx, p, 21) }()
+	// still over, the publish waits for the next tick
+	ticks <- time.Time{}
+	p.mu.Lock()
+	p.pNode.Size = 50
+	p.mu.Unlock()
+	ticks <- time.Time{}
+	assert.Nil(t, <-done)
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() { done <- s.checkQuota(ctx, p, 71) }()
+	ticks <- time.Time{}
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+
+	// never fits
+	err = s.checkQuota(context.Background(), p, 121)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
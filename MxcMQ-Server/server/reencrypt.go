@@ -0,0 +1,57 @@
+package server
+
+import (
+	"MxcMQ-Server/logger"
+	"MxcMQ-Server/persist"
+	"fmt"
+)
+
+// Reencrypt rewrites the messages of topic which are not encrypted with the
+// active key of the keyring, offloaded ranges included, so that old keys can
+// be dropped from the keyring afterwards. The caller holds persist.LockData,
+// no broker may work on the same files meanwhile.
+func (s *Server) Reencrypt(topic string) error {
+	tNode, err := s.meta.GetTopic(topic)
+	if err != nil {
+		return err
+	}
+
+	for i := 1; i <= tNode.Pnum; i++ {
+		n, err := s.store.Rewrite(topic, i)
+		if err != nil {
+			return fmt.Errorf("rewrite %v/%v: %w", topic, i, err)
+		}
+		logger.Infof("re-encrypt %v messages of %v/p%v", n, topic, i)
+
+		if s.objects == nil {
+			continue
+		}
+		pNode, err := s.meta.GetPartition(topic, i)
+		if err != nil {
+			return err
+		}
+		for _, r := range pNode.Offloaded {
+			for _, obj := range objectsIn(r, r.Start, r.End) {
+				if err := s.reencryptOffloaded(offloadObject(topic, i, obj)); err != nil {
+					return fmt.Errorf("rewrite %v: %w", offloadObject(topic, i, obj), err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) reencryptOffloaded(name string) error {
+	data, err := s.objects.Get(name)
+	if err != nil {
+		return err
+	}
+	msgs, err := persist.DecodeRange(data)
+	if err != nil {
+		return err
+	}
+	if data, err = persist.EncodeRange(msgs); err != nil {
+		return err
+	}
+	return s.objects.Put(name, data)
+}
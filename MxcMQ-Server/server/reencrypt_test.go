@@ -0,0 +1,72 @@
+package server
+
+import (
+	"MxcMQ-Server/config"
+	"MxcMQ-Server/msg"
+	"MxcMQ-Server/persist"
+	rc "MxcMQ-Server/registrationCenter"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// useKeyring makes the store encrypt with the active key of the given ones.
+func useKeyring(t *testing.T, active uint32, ids ...uint32) {
+	k := &persist.Keyring{Active: active, Keys: make(map[uint32][]byte)}
+	for _, id := range ids {
+		k.Keys[id] = bytes.Repeat([]byte{byte(id)}, 32)
+	}
+	data, err := json.Marshal(k)
+	assert.Nil(t, err)
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	assert.Nil(t, os.WriteFile(path, data, 0600))
+	k, err = persist.LoadKeyring(path)
+	assert.Nil(t, err)
+	persist.SetKeyring(k)
+}
+
+func TestReencrypt(t *testing.T) {
+	config.StConf.OffloadRangeMsgs = 3
+	defer func() { config.StConf.OffloadRangeMsgs = 0 }()
+	defer persist.SetKeyring(nil)
+
+	s, p := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1, Offload: rc.OffloadPolicy{MaxHotMsgs: 2}})
+	store, err := persist.NewSegmentStore(t.TempDir(), 512, 64)
+	assert.Nil(t, err)
+	defer store.Close()
+	s.store = store
+	s.objects, err = persist.NewFileObjectStore(t.TempDir())
+	assert.Nil(t, err)
+
+	useKeyring(t, 1, 1)
+	for i := 1; i <= 10; i++ {
+		assert.Nil(t, s.commitMsgs(p, &msg.MsgData{Payload: []byte(fmt.Sprintf("m%v", i))}))
+	}
+	assert.Nil(t, s.offload(p))
+	assert.Equal(t, uint64(6), p.pNode.OffloadOffset)
+
+	// read without the loaded partition, nothing is cached
+	s.partitions.Delete(fmt.Sprintf(partitionKey, "t", 1))
+	useKeyring(t, 2, 2)
+	_, err = s.readMsgs("t", 1, 1, 1)
+	assert.True(t, errors.Is(err, persist.ErrUnknownKey))
+	_, err = s.readMsgs("t", 1, 10, 10)
+	assert.True(t, errors.Is(err, persist.ErrUnknownKey))
+
+	// rotate, key 1 goes once every message is encrypted with key 2
+	useKeyring(t, 2, 1, 2)
+	assert.Nil(t, s.Reencrypt("t"))
+	useKeyring(t, 2, 2)
+	msgs, err := s.readMsgs("t", 1, 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, msids(msgs))
+	for i, m := range msgs {
+		assert.Equal(t, fmt.Sprintf("m%v", i+1), string(m.Payload))
+	}
+}
@@ -0,0 +1,222 @@
+package server
+
+import (
+	"MxcMQ-Server/config"
+	"MxcMQ-Server/logger"
+	rc "MxcMQ-Server/registrationCenter"
+	"fmt"
+	"time"
+)
+
+// number of messages read at a time while looking for expired ones
+const reapBatchSize = 100
+
+func (s *Server) startReaper(p *partitionData) {
+	p.reaperOnce.Do(func() {
+		s.reapers.Add(1)
+		go func() {
+			defer s.reapers.Done()
+			s.reap(p)
+		}()
+	})
+}
+
+// reap applies the retention policy of the topic to p periodically, and
+// compacts p if the topic asks for it. It also picks up quota and ttl
+// changes and moves cursors past expired messages, until the server shuts
+// down.
+func (s *Server) reap(p *partitionData) {
+	interval := config.SrvConf.RetentionCheckInterval
+	if interval <= 0 {
+		interval = 60
+	}
+	ticker := time.NewTicker(time.Second * time.Duration(interval))
+	defer ticker.Stop()
+
+	compactInterval := config.SrvConf.CompactionInterval
+	if compactInterval <= 0 {
+		compactInterval = 300
+	}
+	compactTicker := time.NewTicker(time.Second * time.Duration(compactInterval))
+	defer compactTicker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if _, err := s.refreshPolicies(p); err != nil {
+				logger.Errorf("refreshPolicies failed: %v", err)
+			}
+			s.skipExpired(p)
+			s.forgetInactiveProducers(p)
+			if err := s.applyRetention(p); err != nil {
+				logger.Errorf("applyRetention failed: %v", err)
+			}
+			if err := s.offload(p); err != nil {
+				logger.Errorf("offload failed: %v", err)
+			}
+			// catch up with acks received before the broker owned p
+			s.collectAcked(p)
+		case <-compactTicker.C:
+			if err := s.compact(p); err != nil {
+				logger.Errorf("compact failed: %v", err)
+			}
+		}
+	}
+}
+
+func (s *Server) applyRetention(p *partitionData) error {
+	tNode, err := s.meta.GetTopic(p.pNode.TopicName)
+	if err != nil {
+		return err
+	}
+	policy := tNode.Retention
+	if policy == (rc.RetentionPolicy{}) {
+		return nil
+	}
+
+	floor, err := s.deleteFloor(p, policy.DeleteUnacked)
+	if err != nil {
+		return err
+	}
+	return s.deleteBefore(p, floor, func(first, last uint64, size int64) (uint64, int64, error) {
+		return s.expiredTo(p, policy, first, last, size, floor)
+	})
+}
+
+// deleteFloor returns the largest msid that may be deleted from p, it is the
+// AckOffset of the slowest subscription unless unacked messages can go.
+func (s *Server) deleteFloor(p *partitionData, deleteUnacked bool) (uint64, error) {
+	if deleteUnacked {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.pNode.Mnum, nil
+	}
+	floor, _, err := s.ackFloor(p)
+	return floor, err
+}
+
+// ackFloor returns the AckOffset of the slowest subscription of p, capped by
+// Mnum and kept under the delayed messages not delivered yet. ok is false if
+// p has no subscription.
+func (s *Server) ackFloor(p *partitionData) (floor uint64, ok bool, err error) {
+	p.mu.Lock()
+	topic, partition, floor := p.pNode.TopicName, p.pNode.ID, p.pNode.Mnum
+	p.mu.Unlock()
+
+	sNodes, err := s.meta.GetSubs(topic, partition)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, sNode := range sNodes {
+		key := fmt.Sprintf(subcriptionKey, sNode.TopicName, sNode.Partition, sNode.Name)
+		s.Sl.mu.RLock()
+		sub, ok := s.Sl.Subs[key]
+		s.Sl.mu.RUnlock()
+		if !ok {
+			if sub, err = s.GetSubcription(sNode); err != nil {
+				return 0, false, err
+			}
+		}
+		sub.mu.Lock()
+		if sub.Data.AckOffset < floor {
+			floor = sub.Data.AckOffset
+		}
+		if sub.Data.DelayedFloor != 0 && sub.Data.DelayedFloor <= floor {
+			floor = sub.Data.DelayedFloor - 1
+		}
+		sub.mu.Unlock()
+	}
+	return floor, len(sNodes) > 0, nil
+}
+
+// expiredTo walks the messages of p from first and returns the last msid the
+// policy drops, together with the payload bytes up to it.
+func (s *Server) expiredTo(p *partitionData, policy rc.RetentionPolicy, first, last uint64, size int64, floor uint64) (uint64, int64, error) {
+	end := first - 1
+	if policy.MaxMsgs > 0 && last-end > policy.MaxMsgs {
+		end = last - policy.MaxMsgs
+	}
+	if end > floor {
+		end = floor
+	}
+	if policy.MaxAge <= 0 && policy.MaxBytes <= 0 && end == first-1 {
+		return end, 0, nil
+	}
+
+	deadline := time.Now().Add(-time.Second * time.Duration(policy.MaxAge)).UnixMilli()
+	var freed int64
+	for i := first; i <= last && i <= floor; i += reapBatchSize {
+		msgs, err := s.readMsgs(p.pNode.TopicName, p.pNode.ID, i, i+reapBatchSize-1)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, m := range msgs {
+			if m.Msid > floor {
+				return end, freed, nil
+			}
+			// messages written before retention existed have no PublishTime
+			expired := policy.MaxAge > 0 && m.PublishTime < deadline
+			oversize := policy.MaxBytes > 0 && size-freed > policy.MaxBytes
+			if m.Msid > end && !expired && !oversize {
+				return end, freed, nil
+			}
+			if m.Msid > end {
+				end = m.Msid
+			}
+			freed += int64(len(m.Payload))
+		}
+	}
+	return end, freed, nil
+}
+
+// deleteBefore deletes the messages of p chosen by pick, never above floor,
+// and records the new DeleteOffset and Size of the partition. Retention, ack
+// gc and quota eviction delete from p in turn, so DeleteOffset only grows and
+// no message is freed twice.
+func (s *Server) deleteBefore(p *partitionData, floor uint64, pick func(first, last uint64, size int64) (uint64, int64, error)) error {
+	p.deleteMu.Lock()
+	defer p.deleteMu.Unlock()
+
+	p.mu.Lock()
+	first, last, size := p.pNode.DeleteOffset+1, p.pNode.Mnum, p.pNode.Size
+	p.mu.Unlock()
+	if first > last || first > floor {
+		return nil
+	}
+
+	end, freed, err := pick(first, last, size)
+	if err != nil {
+		return err
+	}
+	if end < first {
+		return nil
+	}
+	if end > floor {
+		if end, freed, err = s.payloadTo(p, first, floor); err != nil {
+			return err
+		}
+	}
+
+	if err := s.store.Delete(p.pNode.TopicName, p.pNode.ID, first, end); err != nil {
+		return err
+	}
+	s.cache.removeRange(p.pNode.TopicName, p.pNode.ID, first, end)
+	logger.Infof("delete messages %v-%v of %v/p%v", first, end, p.pNode.TopicName, p.pNode.ID)
+
+	p.mu.Lock()
+	p.pNode.DeleteOffset = end
+	p.pNode.Size -= freed
+	if p.pNode.Size < 0 {
+		p.pNode.Size = 0
+	}
+	trimmed := trimOffloaded(p, end)
+	err = s.meta.UpdatePartition(p.pNode)
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	s.deleteOffloaded(p.pNode.TopicName, p.pNode.ID, trimmed)
+	return nil
+}
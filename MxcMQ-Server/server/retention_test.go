@@ -0,0 +1,192 @@
+package server
+
+import (
+	"MxcMQ-Server/msg"
+	"MxcMQ-Server/persist"
+	rc "MxcMQ-Server/registrationCenter"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newRetentionServer returns a server holding partition t/p1 with 10
+// messages of 10 bytes, the first 5 published two hours ago.
+func newRetentionServer(t *testing.T, policy rc.RetentionPolicy, ackOffsets ...uint64) (*Server, *partitionData) {
+	meta := newMemMeta()
+	assert.Nil(t, meta.RegisterTnode(&rc.TopicNode{Name: "t", Pnum: 1, Retention: policy}))
+	pNode := &rc.PartitionNode{TopicName: "t", ID: 1, Mnum: 10, Size: 100}
+	assert.Nil(t, meta.RegisterPnode(pNode))
+
+	s := &Server{store: persist.NewMemoryStore(), meta: meta, Sl: NewSublist(), cache: newMsgCache(1 << 20)}
+	now := time.Now()
+	for i := uint64(1); i <= 10; i++ {
+		m := &msg.MsgData{Msid: i, Payload: []byte("0123456789"), PublishTime: now.UnixMilli()}
+		if i <= 5 {
+			m.PublishTime = now.Add(-2 * time.Hour).UnixMilli()
+		}
+		assert.Nil(t, s.store.Append("t", 1, m))
+	}
+	for i, ackOffset := range ackOffsets {
+		sNode := &rc.SubcriptionNode{Name: fmt.Sprintf("s%v", i), TopicName: "t", Partition: 1}
+		assert.Nil(t, meta.RegisterSnode(sNode))
+		sub := NewSubcription()
+		sub.Data.Meta = *sNode
+		sub.Data.AckOffset = ackOffset
+		s.Sl.Subs[fmt.Sprintf(subcriptionKey, "t", 1, sNode.Name)] = sub
+	}
+
+	p := &partitionData{pNode: pNode}
+	s.partitions.Store(fmt.Sprintf(partitionKey, "t", 1), p)
+	return s, p
+}
+
+func TestExpiredTo(t *testing.T) {
+	s, p := newRetentionServer(t, rc.RetentionPolicy{})
+
+	tests := []struct {
+		policy rc.RetentionPolicy
+		floor  uint64
+		end    uint64
+		freed  int64
+	}{
+		{rc.RetentionPolicy{}, 10, 0, 0},
+		{rc.RetentionPolicy{MaxAge: 3600}, 10, 5, 50},
+		{rc.RetentionPolicy{MaxBytes: 30}, 10, 7, 70},
+		{rc.RetentionPolicy{MaxMsgs: 4}, 10, 6, 60},
+		{rc.RetentionPolicy{MaxAge: 3600, MaxMsgs: 8}, 10, 5, 50},
+		{rc.RetentionPolicy{MaxMsgs: 4}, 3, 3, 30},
+	}
+	for _, tt := range tests {
+		end, freed, err := s.expiredTo(p, tt.policy, 1, 10, 100, tt.floor)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.end, end, "%+v", tt.policy)
+		assert.Equal(t, tt.freed, freed, "%+v", tt.policy)
+	}
+}
+
+func TestDeleteBefore(t *testing.T) {
+	s, p := newRetentionServer(t, rc.RetentionPolicy{})
+	for msid := uint64(4); msid <= 5; msid++ {
+		s.cache.put("t", 1, &msg.MsgData{Msid: msid})
+	}
+
+	// never above floor, whatever pick says
+	assert.Nil(t, s.deleteBefore(p, 4, func(first, last uint64, size int64) (uint64, int64, error) {
+		assert.Equal(t, uint64(1), first)
+		assert.Equal(t, uint64(10), last)
+		return 8, 80, nil
+	}))
+	assert.Equal(t, uint64(4), p.pNode.DeleteOffset)
+	assert.Equal(t, int64(60), p.pNode.Size)
+	msgs, err := s.store.Read("t", 1, 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), msgs[0].Msid)
+	// a pull does not find them in the cache either
+	_, ok := s.cache.get("t", 1, 4)
+	assert.False(t, ok)
+	_, ok = s.cache.get("t", 1, 5)
+	assert.True(t, ok)
+
+	stored, err := s.meta.GetPartition("t", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4), stored.DeleteOffset)
+	assert.Equal(t, int64(60), stored.Size)
+
+	// nothing below DeleteOffset is picked again
+	assert.Nil(t, s.deleteBefore(p, 10, func(first, last uint64, size int64) (uint64, int64, error) {
+		assert.Equal(t, uint64(5), first)
+		assert.Equal(t, int64(60), size)
+		return first - 1, 0, nil
+	}))
+	assert.Equal(t, uint64(4), p.pNode.DeleteOffset)
+}
+
+func TestApplyRetention(t *testing.T) {
+	tests := []struct {
+		policy       rc.RetentionPolicy
+		ackOffsets   []uint64
+		deleteOffset uint64
+	}{
+		{rc.RetentionPolicy{MaxAge: 3600}, nil, 5},
+		{rc.RetentionPolicy{MaxBytes: 30}, nil, 7},
+		{rc.RetentionPolicy{MaxMsgs: 4}, nil, 6},
+		// the slowest subscription holds everything it did not ack
+		{rc.RetentionPolicy{MaxMsgs: 4}, []uint64{8, 3}, 3},
+		{rc.RetentionPolicy{MaxAge: 3600}, []uint64{2, 10}, 2},
+		{rc.RetentionPolicy{MaxMsgs: 4, DeleteUnacked: true}, []uint64{8, 3}, 6},
+	}
+	for _, tt := range tests {
+		s, p := newRetentionServer(t, tt.policy, tt.ackOffsets...)
+		assert.Nil(t, s.applyRetention(p))
+		assert.Equal(t, tt.deleteOffset, p.pNode.DeleteOffset, "%+v %v", tt.policy, tt.ackOffsets)
+		assert.Equal(t, int64(100-10*tt.deleteOffset), p.pNode.Size)
+		msgs, err := s.store.Read("t", 1, 1, 10)
+		assert.Nil(t, err)
+		assert.Len(t, msgs, int(10-tt.deleteOffset))
+	}
+}
+
+func TestDeleteBeforeConcurrent(t *testing.T) {
+	s, p := newRetentionServer(t, rc.RetentionPolicy{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := s.deleteBefore(p, 10, func(first, last uint64, size int64) (uint64, int64, error) {
+				// as slow as reading a store
+				time.Sleep(time.Millisecond)
+				return s.payloadTo(p, first, first+1)
+			})
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint64(10), p.pNode.DeleteOffset)
+	assert.Equal(t, int64(0), p.pNode.Size)
+	stored, err := s.meta.GetPartition("t", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), stored.DeleteOffset)
+}
+
+func TestDeleteAcked(t *testing.T) {
+	s, p := newRetentionServer(t, rc.RetentionPolicy{DeleteAcked: true}, 7, 4)
+
+	// only what both subscriptions acked goes
+	assert.Nil(t, s.deleteAcked(p))
+	assert.Equal(t, uint64(4), p.pNode.DeleteOffset)
+	assert.Equal(t, int64(60), p.pNode.Size)
+	msgs, err := s.store.Read("t", 1, 1, 10)
+	assert.Nil(t, err)
+	assert.Len(t, msgs, 6)
+	assert.Equal(t, uint64(5), msgs[0].Msid)
+
+	s.Sl.Subs[fmt.Sprintf(subcriptionKey, "t", 1, "s1")].ackCumulative(9)
+	assert.Nil(t, s.deleteAcked(p))
+	assert.Equal(t, uint64(7), p.pNode.DeleteOffset)
+	assert.Equal(t, int64(30), p.pNode.Size)
+}
+
+func TestReaperStops(t *testing.T) {
+	s, p := newRetentionServer(t, rc.RetentionPolicy{})
+	s.done = make(chan struct{})
+	s.startReaper(p)
+	s.startReaper(p)
+
+	close(s.done)
+	stopped := make(chan struct{})
+	go func() {
+		s.reapers.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("reaper still running after shutdown")
+	}
+}
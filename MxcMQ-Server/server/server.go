@@ -2,6 +2,7 @@ package server
 
 import (
 	"MxcMQ-Server/bundle"
+	"MxcMQ-Server/codec"
 	ct "MxcMQ-Server/collect"
 	"MxcMQ-Server/config"
 	"MxcMQ-Server/logger"
@@ -23,7 +24,6 @@ import (
 	pb "MxcMQ-Server/proto"
 
 	"github.com/samuel/go-zookeeper/zk"
-	clientv3 "go.etcd.io/etcd/client/v3"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
@@ -37,12 +37,22 @@ type Server struct {
 	ps         map[string]*partitionData
 	partitions sync.Map
 
-	gcid    uint64 // deprecate
-	kv      clientv3.KV
-	bundles *bundle.Bundles
+	gcid        uint64 // deprecate
+	corruptMsgs uint64 // failed checksum on read
+	expiredMsgs uint64 // skipped by subscriptions after their ttl
+	store       persist.MessageStore
+	cursors     persist.ObjectStore // the state of the subscriptions
+	objects     persist.ObjectStore // nil if offloading is off
+	meta        metaStore
+	cache       *msgCache
+	bundles     *bundle.Bundles
 
-	grpcServer *grpc.Server
-	conns      sync.Map
+	done    chan struct{} // closed by ShutDown, stops the reapers
+	reapers sync.WaitGroup
+
+	grpcServer    *grpc.Server
+	conns         sync.Map
+	legacyClients sync.Map // names of the clients without binaryPayload
 
 	// bundle2broker map[bundle.BundleInfo]rc.BrokerNode
 
@@ -54,8 +64,21 @@ type Server struct {
 type partitionData struct {
 	mu     sync.Mutex
 	pNode  *rc.PartitionNode
-	msgs   sync.Map
 	pubers []int64
+
+	writerOnce sync.Once
+	pending    chan *pubRequest
+
+	reaperOnce sync.Once
+	collecting int32
+	deleteMu   sync.Mutex           // held by deleteBefore and compact, one delete at a time
+	compacted  map[string]keyLatest // latest msid of every key, nil until compacted once
+
+	coldMu sync.Mutex
+	cold   *offloadedRange
+
+	quota      *rc.QuotaPolicy // nil until read from zk
+	messageTTL int64           // second, read with quota
 }
 
 const (
@@ -105,11 +128,25 @@ const (
 
 var defaultSendSize int
 
+var errNoValue = errors.New("not has value")
+
+// the grpc default for received messages
+const defaultMaxMessageSize = 4 << 20
+
+// maxMessageSize returns the bytes a request may hold, a compressed payload
+// is not decompressed past it either.
+func maxMessageSize() int {
+	if config.SrvConf.MaxMessageSize <= 0 {
+		return defaultMaxMessageSize
+	}
+	return config.SrvConf.MaxMessageSize
+}
+
 func NewServerFromConfig() *Server {
 	s := &Server{
-		ps: make(map[string]*partitionData),
-		kv: clientv3.NewKV(persist.EtcdCli),
-		Sl: NewSublist(),
+		ps:   make(map[string]*partitionData),
+		Sl:   NewSublist(),
+		done: make(chan struct{}),
 		// bundle2broker: make(map[bundle.BundleInfo]rc.BrokerNode),
 	}
 	s.Info = &rc.BrokerNode{
@@ -126,7 +163,25 @@ func NewServerFromConfig() *Server {
 	s.Info.Load.BandwidthIn.Limit = config.SrvConf.BandwidthInLimit
 	s.Info.Load.BandwidthOut.Limit = config.SrvConf.BandwidthOutLimit
 
-	s.grpcServer = grpc.NewServer()
+	store, err := persist.NewMessageStore()
+	if err != nil {
+		panic(logger.Errorf("NewMessageStore failed: %v", err))
+	}
+	s.meta = rc.ZkCli
+	s.store = store
+	cursors, err := persist.NewCursorStore()
+	if err != nil {
+		panic(logger.Errorf("NewCursorStore failed: %v", err))
+	}
+	s.cursors = cursors
+	objects, err := persist.NewObjectStore()
+	if err != nil {
+		panic(logger.Errorf("NewObjectStore failed: %v", err))
+	}
+	s.objects = objects
+	s.cache = newMsgCache(config.SrvConf.MsgCacheBytes)
+
+	s.grpcServer = grpc.NewServer(grpc.MaxRecvMsgSize(maxMessageSize()))
 
 	s.loadManager = lm.NewLoadManager(s.Info)
 	return s
@@ -174,6 +229,11 @@ func (s *Server) RunWithGrpc() {
 
 func (s *Server) ShutDown() {
 	s.grpcServer.GracefulStop()
+	close(s.done)
+	s.reapers.Wait()
+	if err := s.store.Close(); err != nil {
+		logger.Errorf("close message store failed: %v", err)
+	}
 	// notify registry
 }
 
@@ -221,11 +281,7 @@ func (s *Server) createClient(conn net.Conn) *client {
 // }
 func (s *Server) PutMsg(m *msg.PubArg, mData msg.MsgData) (string, error) {
 	key := fmt.Sprintf(msgKey, m.Topic, m.Partition, mData.Msid)
-	data, err := json.Marshal(mData)
-	if err != nil {
-		return "", err
-	}
-	return key, s.put(key, data)
+	return key, s.store.Append(m.Topic, m.Partition, &mData)
 }
 
 func (s *Server) PutSubcription(sub *subcription) error {
@@ -235,18 +291,13 @@ func (s *Server) PutSubcription(sub *subcription) error {
 	if err != nil {
 		return err
 	}
-	return s.put(key, data)
-}
-
-func (s *Server) put(key string, data []byte) error {
-	_, err := s.kv.Put(context.TODO(), key, string(data))
-	return err
+	return s.cursors.Put(key, data)
 }
 
 func (s *Server) GetSubcription(sNode *rc.SubcriptionNode) (*subcription, error) {
 	sub := NewSubcription()
 	key := fmt.Sprintf(subcriptionKey, sNode.TopicName, sNode.Partition, sNode.Name)
-	data, err := s.get(key)
+	data, err := s.cursors.Get(key)
 	if err != nil {
 		return nil, err
 	}
@@ -254,44 +305,36 @@ func (s *Server) GetSubcription(sNode *rc.SubcriptionNode) (*subcription, error)
 	if err = json.Unmarshal(data, sub.Data); err != nil {
 		return nil, err
 	}
+	// the held messages were lost with the broker, the cursor reads them again
+	if sub.Data.HeldFloor != 0 && sub.Data.PushOffset > sub.Data.HeldFloor {
+		sub.Data.PushOffset = sub.Data.HeldFloor
+	}
+	sub.Data.HeldFloor = 0
 	//TODO: connect between broker and suber ?
 	return sub, nil
 }
 
 func (s *Server) GetMsg(pua *msg.PullArg, msid uint64) (*msg.MsgData, error) {
-	m := &msg.MsgData{}
 	key := fmt.Sprintf(msgKey, pua.Topic, pua.Partition, msid)
-	data, err := s.get(key)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = json.Unmarshal(data, m); err != nil {
-		return nil, err
+	msgs, err := s.readMsgs(pua.Topic, pua.Partition, msid, msid)
+	if errors.Is(err, persist.ErrCorruptRecord) {
+		return nil, fmt.Errorf("key: %v %w: %v", key, msg.ErrCorruptMsg, err)
 	}
-	return m, nil
-}
-
-func (s *Server) get(key string) ([]byte, error) {
-	resp, err := s.kv.Get(context.TODO(), key)
 	if err != nil {
 		return nil, err
 	}
-	//TODO: resp.Count
-	if len(resp.Kvs) <= 0 {
-		return nil, errors.New(fmt.Sprintf("key: %v not has value", key))
+	if len(msgs) <= 0 {
+		return nil, fmt.Errorf("key: %v %w", key, errNoValue)
+	}
+	if err := msgs[0].Verify(); err != nil {
+		return nil, fmt.Errorf("key: %v %w", key, err)
 	}
-	return resp.Kvs[0].Value, nil
+	s.cache.put(pua.Topic, pua.Partition, msgs[0])
+	return msgs[0], nil
 }
 
 func (s *Server) DeleteMsg(topic string, partition int, msid uint64) error {
-	key := fmt.Sprintf(msgKey, topic, partition, msid)
-	return s.pDelete(key)
-}
-
-func (s *Server) pDelete(key string) error {
-	_, err := s.kv.Delete(context.TODO(), key)
-	return err
+	return s.store.Delete(topic, partition, msid, msid)
 }
 
 func (s *Server) Connect(ctx context.Context, args *pb.ConnectArgs) (*pb.ConnectReply, error) {
@@ -302,13 +345,26 @@ func (s *Server) Connect(ctx context.Context, args *pb.ConnectArgs) (*pb.Connect
 		return reply, err
 	}
 
-	tNode, err := rc.ZkCli.GetTopic(args.Topic)
+	if !codec.CompressionType(args.Compression).Valid() {
+		conn.Close()
+		return reply, fmt.Errorf("unknown compression type: %v", args.Compression)
+	}
+
+	tNode, err := s.meta.GetTopic(args.Topic)
 	if err != nil {
 		if err == zk.ErrNoNode {
 			topicNode := &rc.TopicNode{
-				Name:       args.Topic,
-				Pnum:       int(args.PartitionNum),
-				PulishMode: int(args.PubMode),
+				Name:        args.Topic,
+				Pnum:        int(args.PartitionNum),
+				PulishMode:  int(args.PubMode),
+				Compression: int(args.Compression),
+				Retention: rc.RetentionPolicy{
+					MaxAge:      config.SrvConf.DefaultRetentionMaxAge,
+					MaxBytes:    config.SrvConf.DefaultRetentionMaxBytes,
+					MaxMsgs:     config.SrvConf.DefaultRetentionMaxMsgs,
+					DeleteAcked: config.SrvConf.DefaultDeleteAcked,
+				},
+				MessageTTL: config.SrvConf.DefaultMessageTTL,
 			}
 			if err := s.registerTopic(topicNode); err != nil {
 				logger.Errorf("registerTopic failed: %v", err)
@@ -333,7 +389,7 @@ func (s *Server) Connect(ctx context.Context, args *pb.ConnectArgs) (*pb.Connect
 		Version:    0,
 		// Url: ,
 	}
-	if err := rc.ZkCli.RegisterPnode(pNode); err != nil && err != zk.ErrNodeExists {
+	if err := s.meta.RegisterPnode(pNode); err != nil && err != zk.ErrNodeExists {
 		logger.Errorf("RegisterPnode failed: %v", err)
 		conn.Close()
 		return reply, errors.New("404")
@@ -419,8 +475,48 @@ func (s *Server) Connect(ctx context.Context, args *pb.ConnectArgs) (*pb.Connect
 		preName = preName + "-subscriber-" + args.Name
 	}
 
+	curName, err := s.connName(preName, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Type == Puber || args.Type == PartPuber {
+		seq, err := s.lastSequenceId(args.Topic, int(args.Partition), args.Name)
+		if err != nil {
+			logger.Errorf("lastSequenceId failed: %v", err)
+			conn.Close()
+			return reply, errors.New("404")
+		}
+		reply.LastSequenceId = seq
+	}
+
+	s.conns.Store(curName, conn)
+	// only subscribers are sent payloads, the entry goes when SuberAlive
+	// finds the subscriber gone or it connects again under the same name
+	if args.Type == Suber && !args.BinaryPayload {
+		s.legacyClients.Store(curName, true)
+	} else {
+		s.legacyClients.Delete(curName)
+	}
+	reply.Name = curName
+	reply.Compression = int32(tNode.Compression)
+	if preName != curName {
+		return reply, errors.New("Automatically rename")
+	}
+	logger.Debugf("Connect reply: %v", reply)
+	return reply, nil
+}
+
+// connName returns the name a client connects under, preName unless a
+// client is connected under it already. A producer is not renamed with
+// deduplication on, the sequence ids are kept by the name it gives.
+func (s *Server) connName(preName string, args *pb.ConnectArgs) (string, error) {
+	rename := config.SrvConf.AllowRenameForClient
+	if args.Type == Puber || args.Type == PartPuber {
+		rename = rename && !config.SrvConf.BrokerDeduplicationEnabled
+	}
 	curName := preName
-	if config.SrvConf.AllowRenameForClient {
+	if rename {
 		index := 1
 		for {
 			if _, ok := s.conns.Load(curName); ok {
@@ -434,17 +530,10 @@ func (s *Server) Connect(ctx context.Context, args *pb.ConnectArgs) (*pb.Connect
 	} else {
 		if _, ok := s.conns.Load(curName); ok {
 			logger.Infoln("Name conflict, rename plz")
-			return nil, errors.New("Name conflict, rename plz")
+			return "", errors.New("Name conflict, rename plz")
 		}
 	}
-
-	s.conns.Store(curName, conn)
-	reply.Name = curName
-	if preName != curName {
-		return reply, errors.New("Automatically rename")
-	}
-	logger.Debugf("Connect reply: %v", reply)
-	return reply, nil
+	return curName, nil
 }
 
 func handlePmode_wait(ctx context.Context, args *pb.ConnectArgs, over chan<- error) {
@@ -476,7 +565,7 @@ func handleSmode_Failover(ctx context.Context, args *pb.SubscribeArgs, over chan
 }
 
 func (s *Server) registerTopic(tNode *rc.TopicNode) error {
-	if err := rc.ZkCli.RegisterTnode(tNode); err != nil {
+	if err := s.meta.RegisterTnode(tNode); err != nil {
 		return err
 	}
 
@@ -508,13 +597,13 @@ func (s *Server) ProcessSub(ctx context.Context, args *pb.SubscribeArgs) (*pb.Su
 	if sub, ok := s.Sl.Subs[key]; ok {
 		exSub = sub
 	} else {
-		isExists, err := rc.ZkCli.IsSubcriptionExist(snode)
+		isExists, err := s.meta.IsSubcriptionExist(snode)
 		if err != nil {
 			logger.Errorf("IsSubcriptionExist failed: %v", err)
 			return reply, errors.New("404")
 		}
 		if isExists {
-			existSnode, err := rc.ZkCli.GetSub(snode)
+			existSnode, err := s.meta.GetSub(snode)
 			if err != nil {
 				logger.Errorf("GetSub failed: %v", err)
 				return reply, errors.New("404")
@@ -529,12 +618,18 @@ func (s *Server) ProcessSub(ctx context.Context, args *pb.SubscribeArgs) (*pb.Su
 				return reply, errors.New("404")
 			}
 			exSub = existSdata
+			// what was pushed but not acked before is delivered again
+			exSub.rewind()
+			if err := s.rebuildDelayed(exSub); err != nil {
+				logger.Errorf("rebuildDelayed failed: %v", err)
+				return reply, errors.New("404")
+			}
 			s.Sl.Subs[key] = exSub
 		}
 	}
 
 	if exSub == nil {
-		if err := rc.ZkCli.RegisterSnode(snode); err != nil {
+		if err := s.meta.RegisterSnode(snode); err != nil {
 			logger.Errorf("RegisterSnode failed: %v", err)
 			return reply, errors.New("404")
 		}
@@ -551,6 +646,9 @@ func (s *Server) ProcessSub(ctx context.Context, args *pb.SubscribeArgs) (*pb.Su
 		conn, _ := s.conns.LoadAndDelete(args.Name)
 		exSub.Data.Subers[args.Name] = args.Name
 		exSub.clients[args.Name] = conn.(*grpc.ClientConn)
+		if SubscribeMode(exSub.Data.Meta.Subtype) == SMode_KeyShard {
+			s.joinKeyShared(exSub, args.Name)
+		}
 	} else {
 		switch SubscribeMode(exSub.Data.Meta.Subtype) {
 		case SMode_Exclusive:
@@ -600,7 +698,7 @@ func (s *Server) ProcessSub(ctx context.Context, args *pb.SubscribeArgs) (*pb.Su
 				conn, _ := s.conns.LoadAndDelete(args.Name)
 				exSub.Data.Subers[args.Name] = args.Name
 				exSub.clients[args.Name] = conn.(*grpc.ClientConn)
-				go s.SuberAlive(conn.(*grpc.ClientConn), *args)
+				go s.SuberAlive(conn.(*grpc.ClientConn), args)
 			} else {
 				if err := rc.ZkCli.RegisterLeadSuberNode(args.Topic, int(args.Partition), args.Subscription, args.Id); err != nil {
 					logger.Errorf("RegisterLeadSuberNode failed: %v", err)
@@ -610,7 +708,7 @@ func (s *Server) ProcessSub(ctx context.Context, args *pb.SubscribeArgs) (*pb.Su
 				conn, _ := s.conns.LoadAndDelete(args.Name)
 				exSub.Data.Subers[args.Name] = args.Name
 				exSub.clients[args.Name] = conn.(*grpc.ClientConn)
-				go s.SuberAlive(conn.(*grpc.ClientConn), *args)
+				go s.SuberAlive(conn.(*grpc.ClientConn), args)
 			}
 		case SMode_Shard:
 			conn, _ := s.conns.LoadAndDelete(args.Name)
@@ -624,12 +722,47 @@ func (s *Server) ProcessSub(ctx context.Context, args *pb.SubscribeArgs) (*pb.Su
 				}
 			}
 			//TODO: need some extra action
+		case SMode_KeyShard:
+			conn, _ := s.conns.LoadAndDelete(args.Name)
+			exSub.Data.Subers[args.Name] = args.Name
+			exSub.clients[args.Name] = conn.(*grpc.ClientConn)
+
+			if err := rc.ZkCli.RegisterSuberNode(args.Topic, int(args.Partition), args.Subscription, args.Id); err != nil {
+				if err != zk.ErrNodeExists {
+					logger.Errorf("RegisterSuberNode failed: %v", err)
+					return reply, errors.New("404")
+				}
+			}
+			s.joinKeyShared(exSub, args.Name)
+			go s.SuberAlive(conn.(*grpc.ClientConn), args)
 		}
 	}
 
+	if args.DeadLetter != nil {
+		exSub.mu.Lock()
+		exSub.Data.DeadLetter = DeadLetterPolicy{
+			MaxDeliveries: int(args.DeadLetter.MaxDeliveries),
+			Topic:         args.DeadLetter.Topic,
+		}
+		exSub.mu.Unlock()
+	}
+	if args.AckTimeout > 0 {
+		exSub.mu.Lock()
+		exSub.Data.AckTimeout = args.AckTimeout
+		exSub.mu.Unlock()
+	}
+	if args.NackBackoff != nil {
+		exSub.mu.Lock()
+		exSub.Data.NackBackoff = NackBackoff{
+			MinDelay: args.NackBackoff.MinDelay,
+			MaxDelay: args.NackBackoff.MaxDelay,
+		}
+		exSub.mu.Unlock()
+	}
+
 	name := fmt.Sprintf(partitionKey, args.Topic, args.Partition)
 	if _, ok := s.partitions.Load(name); !ok {
-		pNode, err := rc.ZkCli.GetPartition(args.Topic, int(args.Partition))
+		pNode, err := s.meta.GetPartition(args.Topic, int(args.Partition))
 		if err != nil {
 			logger.Errorf("GetPartition failed: %v", err)
 			return nil, errors.New("404")
@@ -641,21 +774,31 @@ func (s *Server) ProcessSub(ctx context.Context, args *pb.SubscribeArgs) (*pb.Su
 	}
 
 	p, _ := s.partitions.Load(name)
-	p.(*partitionData).mu.Lock()
-	pData := p.(*partitionData)
-	switch args.SubOffset {
-	case 0:
-		sub.Data.PushOffset = pData.pNode.PushOffset + 1
-	default:
-		if sub.Data.PushOffset >= pData.pNode.Mnum {
+	s.startReaper(p.(*partitionData))
+	// only a new subcription starts where it is asked to, an existing one
+	// keeps its cursor and acks
+	if exSub == sub {
+		p.(*partitionData).mu.Lock()
+		pData := p.(*partitionData)
+		switch args.SubOffset {
+		case 0:
 			sub.Data.PushOffset = pData.pNode.PushOffset + 1
-		} else {
-			sub.Data.PushOffset = args.SubOffset
+		default:
+			if sub.Data.PushOffset >= pData.pNode.Mnum {
+				sub.Data.PushOffset = pData.pNode.PushOffset + 1
+			} else {
+				sub.Data.PushOffset = args.SubOffset
+			}
 		}
+		// nothing before the start of a new subscription is to be delivered
+		sub.Data.AckOffset = sub.Data.PushOffset - 1
+		p.(*partitionData).mu.Unlock()
 	}
-	p.(*partitionData).mu.Unlock()
 
-	if err := s.PutSubcription(sub); err != nil {
+	exSub.mu.Lock()
+	err := s.PutSubcription(exSub)
+	exSub.mu.Unlock()
+	if err != nil {
 		reply.Error = err.Error()
 		return reply, err
 	}
@@ -683,6 +826,7 @@ func (s *Server) ProcessPull(ctx context.Context, args *pb.PullArgs) (*pb.PullRe
 	exSub := s.Sl.Subs[skey]
 
 	go pua.CheckTimeout(int(args.Timeout))
+	ttl := s.messageTTLOf(pNode)
 
 	for {
 		select {
@@ -698,44 +842,153 @@ func (s *Server) ProcessPull(ctx context.Context, args *pb.PullArgs) (*pb.PullRe
 				return reply, nil
 			}
 
+			s.redeliverTimedOut(pua, exSub)
 			exSub.mu.Lock()
-			i := exSub.Data.PushOffset
-			if i <= pNode.pNode.Mnum {
-				var m *msg.MsgData
-				key := fmt.Sprintf(msgKey, pua.Topic, pua.Partition, i)
-				if en, ok := pNode.msgs.Load(key); ok {
-					m = en.(*msg.MsgData)
-				} else {
-					ms, err := s.GetMsg(pua, i)
-					if err != nil {
-						reply.Error = err.Error()
-						return reply, err
+			if exSub.keyShared != nil {
+				// messages held for this consumer go first
+				load := func(msid uint64) (*msg.MsgData, error) { return s.loadMsg(pua, msid) }
+				if m := exSub.nextHeld(args.Name, load); m != nil {
+					if expired(m, ttl, time.Now().UnixMilli()) {
+						s.expire(exSub, m)
+						exSub.mu.Unlock()
+						continue
 					}
-					m = ms
+					count := exSub.redeliveryCount(m.Msid)
+					exSub.mu.Unlock()
+					s.pushMsg(args, exSub, m, count)
+					pua.Bufsize--
+					continue
 				}
-				i++
+			}
+			// then the ones which failed before
+			exSub.promoteNacked(time.Now().UnixMilli())
+			if msid, ok := exSub.nextRedeliveryFor(args.Name, time.Now().UnixMilli()); ok {
+				if exSub.isAcked(msid) {
+					exSub.mu.Unlock()
+					continue
+				}
+				m, err := s.loadMsg(pua, msid)
+				if err != nil {
+					logger.Errorf("load redelivered message %v failed: %v", msid, err)
+					exSub.mu.Unlock()
+					continue
+				}
+				if expired(m, ttl, time.Now().UnixMilli()) {
+					s.expire(exSub, m)
+					exSub.mu.Unlock()
+					continue
+				}
+				if exSub.keyShared != nil && !exSub.dispatchKeyShared(args.Name, m) {
+					exSub.mu.Unlock()
+					continue
+				}
+				count := exSub.redeliveryCount(msid)
+				exSub.mu.Unlock()
+				s.pushMsg(args, exSub, m, count)
+				pua.Bufsize--
+				continue
+			}
+			pNode.mu.Lock()
+			deleteOffset, mnum, compactOffset := pNode.pNode.DeleteOffset, pNode.pNode.Mnum, pNode.pNode.CompactOffset
+			pNode.mu.Unlock()
+			// messages under DeleteOffset are gone with retention
+			if exSub.Data.PushOffset <= deleteOffset {
+				exSub.Data.PushOffset = deleteOffset + 1
+			}
+			if msid, ok := exSub.due(time.Now().UnixMilli()); ok {
+				if exSub.isAcked(msid) {
+					exSub.mu.Unlock()
+					continue
+				}
+				m, err := s.loadMsg(pua, msid)
+				if err != nil {
+					// gone with retention or corrupt, nothing to deliver
+					logger.Errorf("load delayed message %v failed: %v", msid, err)
+					exSub.mu.Unlock()
+					continue
+				}
+				if expired(m, ttl, time.Now().UnixMilli()) {
+					s.expire(exSub, m)
+					exSub.mu.Unlock()
+					continue
+				}
+				if exSub.keyShared != nil && !exSub.dispatchKeyShared(args.Name, m) {
+					exSub.mu.Unlock()
+					continue
+				}
+				if err := s.PutSubcription(exSub); err != nil {
+					logger.Errorf("PutSubcription failed: %v", err)
+				}
+				count := exSub.redeliveryCount(msid)
 				exSub.mu.Unlock()
-				mArgs := &pb.MsgArgs{
-					Name:      key,
-					Topic:     args.Topic,
-					Partition: args.Partition,
-					Mid:       m.Mid,
-					Msid:      m.Msid,
-					Payload:   m.Payload,
-					Redo:      0,
-					Suber:     args.Name,
+				s.pushMsg(args, exSub, m, count)
+				pua.Bufsize--
+				continue
+			}
+			i := exSub.Data.PushOffset
+			if i <= mnum {
+				if exSub.isAcked(i) {
+					// acked before the cursor was rewound
+					exSub.Data.PushOffset = i + 1
+					exSub.mu.Unlock()
+					continue
+				}
+				key := fmt.Sprintf(msgKey, pua.Topic, pua.Partition, i)
+				m, err := s.loadMsg(pua, i)
+				if errors.Is(err, errNoValue) && i <= compactOffset {
+					// compacted away, go on with the next msid
+					exSub.ack(i)
+					exSub.Data.PushOffset = i + 1
+					exSub.mu.Unlock()
+					continue
+				}
+				if errors.Is(err, msg.ErrCorruptMsg) {
+					// never hand a corrupt message to subscribers
+					atomic.AddUint64(&s.corruptMsgs, 1)
+					logger.Errorf("skip corrupt message %v: %v", key, err)
+					s.cache.remove(pua.Topic, pua.Partition, i)
+					exSub.ack(i)
+					exSub.Data.PushOffset = i + 1
+					exSub.mu.Unlock()
+					continue
 				}
-				_, err := s.sendMsg(mArgs, exSub, config.SrvConf.OperationTimeout)
 				if err != nil {
-					logger.Errorf("sendMsgWithRedo failed: %v", err)
-					// dead letter
+					exSub.mu.Unlock()
+					reply.Error = err.Error()
+					return reply, err
+				}
+				if expired(m, ttl, time.Now().UnixMilli()) {
+					s.expire(exSub, m)
+					exSub.Data.PushOffset = i + 1
+					exSub.mu.Unlock()
+					continue
 				}
+				if m.DeliverAt > time.Now().UnixMilli() {
+					// hidden until due, the delayed index gives it back
+					exSub.delay(m)
+					exSub.Data.PushOffset = i + 1
+					if err := s.PutSubcription(exSub); err != nil {
+						logger.Errorf("PutSubcription failed: %v", err)
+					}
+					exSub.mu.Unlock()
+					continue
+				}
+				if exSub.keyShared != nil && !exSub.dispatchKeyShared(args.Name, m) {
+					// held for the consumer owning its key
+					exSub.Data.PushOffset = i + 1
+					exSub.mu.Unlock()
+					continue
+				}
+				i++
+				count := exSub.redeliveryCount(m.Msid)
+				exSub.mu.Unlock()
+				s.pushMsg(args, exSub, m, count)
 				pua.Bufsize--
 
 				pNode.mu.Lock()
 				if pNode.pNode.PushOffset < i {
 					pNode.pNode.PushOffset = i
-					if err := rc.ZkCli.UpdatePartition(pNode.pNode); err != nil {
+					if err := s.meta.UpdatePartition(pNode.pNode); err != nil {
 						logger.Errorf("UpdatePartition failed: %v", err)
 					}
 				}
@@ -743,12 +996,14 @@ func (s *Server) ProcessPull(ctx context.Context, args *pb.PullArgs) (*pb.PullRe
 				// switch exSub.Data.Meta.Subtype{
 				// 	case
 				// }
+				exSub.mu.Lock()
 				if exSub.Data.PushOffset < i {
 					exSub.Data.PushOffset = i
 					if err := s.PutSubcription(exSub); err != nil {
 						logger.Errorf("PutSubcription failed: %v", err)
 					}
 				}
+				exSub.mu.Unlock()
 			} else {
 				exSub.mu.Unlock()
 			}
@@ -757,6 +1012,55 @@ func (s *Server) ProcessPull(ctx context.Context, args *pb.PullArgs) (*pb.PullRe
 	// return reply, nil
 }
 
+// loadMsg reads the message msid from the cache or the store.
+func (s *Server) loadMsg(pua *msg.PullArg, msid uint64) (*msg.MsgData, error) {
+	if m, ok := s.cache.get(pua.Topic, pua.Partition, msid); ok {
+		return m, m.Verify()
+	}
+	return s.GetMsg(pua, msid)
+}
+
+// pushMsg sends m to the subscriber pulling with args, m is redelivered or
+// dead lettered if it fails.
+func (s *Server) pushMsg(args *pb.PullArgs, sub *subcription, m *msg.MsgData, redeliveryCount int) {
+	key := fmt.Sprintf(msgKey, args.Topic, args.Partition, m.Msid)
+	mArgs := &pb.MsgArgs{
+		Name:            key,
+		Topic:           args.Topic,
+		Partition:       args.Partition,
+		Mid:             m.Mid,
+		Msid:            m.Msid,
+		Payload:         m.Payload,
+		Redo:            0,
+		Suber:           args.Name,
+		Compression:     int32(m.Compression),
+		Key:             m.Key,
+		Crc:             m.Crc,
+		Properties:      m.Properties,
+		EventTime:       m.EventTime,
+		PublishTime:     m.PublishTime,
+		ProducerName:    m.ProducerName,
+		RedeliveryCount: int32(redeliveryCount),
+	}
+	if _, ok := s.legacyClients.Load(args.Name); ok {
+		if err := textPayload(mArgs); err != nil {
+			logger.Errorf("textPayload of %v failed: %v", key, err)
+		}
+	}
+	// in flight before the consumer may ack or nack it
+	sub.mu.Lock()
+	sub.track(m.Msid, args.Name, time.Now().UnixMilli())
+	sub.mu.Unlock()
+	if _, err := s.sendMsg(mArgs, sub, config.SrvConf.OperationTimeout); err != nil {
+		logger.Errorf("sendMsgWithRedo failed: %v", err)
+		sub.mu.Lock()
+		delete(sub.inflight, m.Msid)
+		sub.mu.Unlock()
+		s.deliveryFailed(sub, m, err.Error())
+		return
+	}
+}
+
 func (s *Server) sendMsgWithRedo(args *pb.MsgArgs, sub *subcription, timeout int) (*pb.MsgReply, error) {
 	if args.Redo >= int32(config.SrvConf.OperationRedoNum) {
 		return nil, errors.New("match max redo")
@@ -815,9 +1119,8 @@ func (s *Server) batchPull(ctx context.Context, args *pb.PullArgs) (*pb.PullRepl
 				break
 			}
 			var m *msg.MsgData
-			key := fmt.Sprintf(msgKey, pua.Topic, pua.Partition, i)
-			if en, ok := pNode.msgs.Load(key); ok {
-				m = en.(*msg.MsgData)
+			if en, ok := s.cache.get(pua.Topic, pua.Partition, i); ok {
+				m = en
 			} else {
 				ms, err := s.GetMsg(pua, i)
 				if err != nil {
@@ -842,7 +1145,7 @@ func (s *Server) batchPull(ctx context.Context, args *pb.PullArgs) (*pb.PullRepl
 		if err != nil {
 			return reply, err
 		}
-		msgArgs := &pb.MsgArgs{Payload: string(payload)}
+		msgArgs := &pb.MsgArgs{Payload: payload}
 
 		c.ProcessMsg(ctx, msgArgs)
 		exSub.Data.PushOffset += uint64(len(msgs))
@@ -861,22 +1164,46 @@ func (s *Server) MsgAck(ctx context.Context, args *pb.MsgAckArgs) (*pb.MsgAckRep
 		pData = p.(*partitionData)
 	}
 
+	skey := fmt.Sprintf(subcriptionKey, args.Topic, args.Partition, args.Subscription)
+	exSub, exist := s.Sl.Subs[skey]
+	if !exist {
+		logger.Errorf("there is no this subcription %v", skey)
+		return reply, errors.New("404")
+	}
+	// the consumers of a shared subcription get messages out of order, one
+	// of them can not ack what the others did not consume yet
+	mode := SubscribeMode(exSub.Data.Meta.Subtype)
+	if args.AckOffset != 0 && (mode == SMode_Shard || mode == SMode_KeyShard) {
+		logger.Warnln("cumulative ack in a shared subcription")
+		return reply, errors.New("cumulative ack is not allowed in a shared subcription")
+	}
+	exSub.mu.Lock()
+	changed := exSub.ackCumulative(args.AckOffset)
+	for _, msid := range args.Msids {
+		if exSub.ack(msid) {
+			delete(exSub.deliveries, msid)
+			changed = true
+		}
+	}
+	ackOffset := exSub.Data.AckOffset
+	if changed {
+		if err := s.PutSubcription(exSub); err != nil {
+			logger.Errorf("PutSubcription failed: %v", err)
+		}
+	}
+	exSub.mu.Unlock()
+
 	pData.mu.Lock()
-	if pData.pNode.AckOffset < args.AckOffset {
-		pData.pNode.AckOffset = args.AckOffset
-		if err := rc.ZkCli.UpdatePartition(pData.pNode); err != nil {
+	if pData.pNode.AckOffset < ackOffset {
+		pData.pNode.AckOffset = ackOffset
+		if err := s.meta.UpdatePartition(pData.pNode); err != nil {
 			logger.Errorf("UpdatePartition failed: %v", err)
 		}
 	}
 	pData.mu.Unlock()
-
-	skey := fmt.Sprintf(subcriptionKey, args.Topic, args.Partition, args.Subscription)
-	exSub := s.Sl.Subs[skey]
-	exSub.mu.Lock()
-	if exSub.Data.AckOffset > args.AckOffset {
-		exSub.Data.AckOffset = args.AckOffset
+	if changed && ok {
+		s.collectAcked(pData)
 	}
-	exSub.mu.Unlock()
 
 	//TODO: retry ?
 	return reply, nil
@@ -899,12 +1226,12 @@ func (s *Server) ProcessUnsub(ctx context.Context, args *pb.UnSubscribeArgs) (*p
 	if sub, ok := s.Sl.Subs[key]; ok {
 		exSub = sub
 	} else {
-		isExists, err := rc.ZkCli.IsSubcriptionExist(&sub.Data.Meta)
+		isExists, err := s.meta.IsSubcriptionExist(&sub.Data.Meta)
 		if err != nil {
 			return nil, err
 		}
 		if isExists {
-			existSnode, err := rc.ZkCli.GetSub(&sub.Data.Meta)
+			existSnode, err := s.meta.GetSub(&sub.Data.Meta)
 			if err != nil {
 				return nil, err
 			}
@@ -921,6 +1248,9 @@ func (s *Server) ProcessUnsub(ctx context.Context, args *pb.UnSubscribeArgs) (*p
 		if _, ok := exSub.Data.Subers[name]; !ok {
 			return nil, errors.New("not exist in this subcription")
 		}
+		if SubscribeMode(exSub.Data.Meta.Subtype) == SMode_KeyShard {
+			s.leaveKeyShared(exSub, name)
+		}
 
 		delete(s.Sl.Subs, name)
 		if err := s.PutSubcription(exSub); err != nil {
@@ -940,16 +1270,27 @@ func (s *Server) ProcessPub(ctx context.Context, args *pb.PublishArgs) (*pb.Publ
 	if v, ok := s.partitions.Load(path); ok {
 		pNode = v.(*partitionData)
 	} else {
-		isExists, err := rc.ZkCli.IsPartitionExists(args.Topic, int(args.Partition))
+		isExists, err := s.meta.IsPartitionExists(args.Topic, int(args.Partition))
 		if err != nil {
 			return reply, err
 		}
 		if isExists {
-			pNode.pNode, err = rc.ZkCli.GetPartition(args.Topic, int(args.Partition))
+			pNode.pNode, err = s.meta.GetPartition(args.Topic, int(args.Partition))
 			if err != nil {
 				logger.Errorf("GetPartition failed: %v", err)
 			}
+			// the store may be ahead of zk if the broker crashed before UpdatePartition
+			last, err := s.store.LastOffset(args.Topic, int(args.Partition))
+			if err != nil {
+				logger.Errorf("LastOffset failed: %v", err)
+			} else if pNode.pNode != nil && last > pNode.pNode.Mnum {
+				if err := s.recoverSequences(pNode.pNode, pNode.pNode.Mnum+1, last); err != nil {
+					logger.Errorf("recoverSequences failed: %v", err)
+				}
+				pNode.pNode.Mnum = last
+			}
 			s.partitions.Store(path, pNode)
+			s.startReaper(pNode)
 		} else {
 			logger.Errorf("there is no this topic/partition %v/%v", args.Topic, args.Partition)
 			return reply, errors.New("404")
@@ -957,31 +1298,68 @@ func (s *Server) ProcessPub(ctx context.Context, args *pb.PublishArgs) (*pb.Publ
 	}
 
 	// todo: check
+	crc := msg.Checksum(args.Payload)
+	if args.Crc != 0 && args.Crc != crc {
+		logger.Errorf("publish %v/%v mid %v: %v", args.Topic, args.Partition, args.Mid, msg.ErrCorruptMsg)
+		return reply, msg.ErrCorruptMsg
+	}
 
-	pNode.mu.Lock()
-	mData := msg.MsgData{
-		Msid:    pNode.pNode.Mnum + 1,
-		Mid:     args.Mid,
-		Payload: args.Payload,
+	var mDatas []*msg.MsgData
+	if args.BatchSize > 0 {
+		batch, err := unbatch(args, time.Now().UnixMilli())
+		if err != nil {
+			logger.Errorf("unbatch %v/%v mid %v failed: %v", args.Topic, args.Partition, args.Mid, err)
+			return reply, err
+		}
+		mDatas = batch
+	} else {
+		mData := &msg.MsgData{
+			Mid:          args.Mid,
+			Key:          args.Key,
+			Payload:      args.Payload,
+			PublishTime:  time.Now().UnixMilli(),
+			EventTime:    args.EventTime,
+			DeliverAt:    args.DeliverAt,
+			ProducerName: args.ProducerName,
+			SequenceId:   args.SequenceId,
+			Properties:   args.Properties,
+			Compression:  codec.CompressionType(args.Compression),
+			Crc:          crc,
+			HasCrc:       true,
+		}
+		if args.Ttl > 0 {
+			mData.ExpireAt = mData.PublishTime + args.Ttl
+		}
+		mDatas = []*msg.MsgData{mData}
 	}
-	pa := &msg.PubArg{
-		Topic:     args.Topic,
-		Partition: int(args.Partition),
-		Mid:       args.Mid,
+	var size int64
+	for _, m := range mDatas {
+		size += int64(len(m.Payload))
 	}
-	if _, err := s.PutMsg(pa, mData); err != nil {
+	if err := s.checkQuota(ctx, pNode, size); err != nil {
+		logger.Errorf("checkQuota of %v/%v failed: %v", args.Topic, args.Partition, err)
 		return reply, err
 	}
-	pNode.pNode.Mnum += 1
-	reply.Msid = pNode.pNode.Mnum
-	if err := rc.ZkCli.UpdatePartition(pNode.pNode); err != nil {
-		logger.Errorf("UpdatePartition: %v", err)
-		p, _ := rc.ZkCli.GetPartition(pNode.pNode.TopicName, pNode.pNode.ID)
-		logger.Debugln(*pNode.pNode, " ", *p)
-		return reply, err
+
+	if config.SrvConf.SyncWrite2disk {
+		if err := s.commitMsgs(pNode, mDatas...); err != nil {
+			return reply, err
+		}
+	} else {
+		if err := s.writeBehind(ctx, pNode, mDatas...); err != nil {
+			return reply, err
+		}
+	}
+	for _, m := range mDatas {
+		if m.Msid != 0 {
+			reply.Msid = m.Msid
+		}
 	}
-	logger.Infof("persist a message: %v %v", pa, mData)
-	pNode.mu.Unlock()
+	if reply.Msid == 0 {
+		reply.Duplicate = true
+		return reply, nil
+	}
+	logger.Infof("persist %v messages: %v/%v up to %v", len(mDatas), args.Topic, args.Partition, reply.Msid)
 
 	return reply, nil
 }
@@ -989,7 +1367,7 @@ func (s *Server) ProcessPub(ctx context.Context, args *pb.PublishArgs) (*pb.Publ
 func (s *Server) GetTopicInfo(ctx context.Context, args *pb.GetTopicInfoArgs) (*pb.GetTopicInfoReply, error) {
 	logger.Infof("Receive GetTopicInfo rq from %v", args)
 	reply := &pb.GetTopicInfoReply{}
-	tNode, err := rc.ZkCli.GetTopic(args.Topic)
+	tNode, err := s.meta.GetTopic(args.Topic)
 	if err != nil {
 		logger.Errorf("GetTopic failed: %v", err)
 		return reply, errors.New("404")
@@ -1025,7 +1403,7 @@ func (s *Server) ClientAlive(conn *grpc.ClientConn, Cargs pb.ConnectArgs) {
 	}
 }
 
-func (s *Server) SuberAlive(conn *grpc.ClientConn, Sargs pb.SubscribeArgs) {
+func (s *Server) SuberAlive(conn *grpc.ClientConn, Sargs *pb.SubscribeArgs) {
 	count := 0
 	cli := pb.NewClientClient(conn)
 	for {
@@ -1036,9 +1414,16 @@ func (s *Server) SuberAlive(conn *grpc.ClientConn, Sargs pb.SubscribeArgs) {
 		if err != nil {
 			count++
 			if count >= config.SrvConf.TimeoutTimes {
-				if err1 := rc.ZkCli.DeleteLeadSuber(Sargs.Topic, int(Sargs.Partition), Sargs.Subscription); err1 != nil {
+				if SubscribeMode(Sargs.Mode) == SMode_KeyShard {
+					// its keys go to the other consumers
+					key := fmt.Sprintf(subcriptionKey, Sargs.Topic, Sargs.Partition, Sargs.Subscription)
+					if sub, ok := s.Sl.Subs[key]; ok {
+						s.leaveKeyShared(sub, Sargs.Name)
+					}
+				} else if err1 := rc.ZkCli.DeleteLeadSuber(Sargs.Topic, int(Sargs.Partition), Sargs.Subscription); err1 != nil {
 					logger.Errorf("DeleteLeadSuber failed: %v", err1)
 				}
+				s.legacyClients.Delete(Sargs.Name)
 				logger.Infof("not alive: %v, err: %v", Sargs, err)
 				return
 			}
@@ -108,7 +108,7 @@ func TestPut(t *testing.T) {
 	}
 	mData := msg.MsgData{
 		Msid:    uint64(msid),
-		Payload: payload,
+		Payload: []byte(payload),
 	}
 	_, err = s.PutMsg(pa, mData)
 	assert.Nil(t, err)
@@ -119,7 +119,7 @@ func TestPut(t *testing.T) {
 	}
 	data, err := s.GetMsg(pua, uint64(msid))
 	assert.Nil(t, err)
-	assert.Equal(t, payload, data.Payload)
+	assert.Equal(t, []byte(payload), data.Payload)
 }
 
 func TestConnect(t *testing.T) {
@@ -155,7 +155,7 @@ func TestPubulish(t *testing.T) {
 	args := &pb.PublishArgs{
 		Topic:     topic,
 		Partition: int32(partition),
-		Payload:   data,
+		Payload:   []byte(data),
 		Mid:       mid,
 	}
 	reply, err := s.ProcessPub(context.TODO(), args)
@@ -165,7 +165,7 @@ func TestPubulish(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, pNode.Mnum+1, msgdata.Msid)
 	assert.Equal(t, mid, msgdata.Mid)
-	assert.Equal(t, data, msgdata.Payload)
+	assert.Equal(t, []byte(data), msgdata.Payload)
 
 	time.Sleep(time.Second)
 	newPNode, err := rc.ZkCli.GetPartition(topic, partition)
@@ -449,7 +449,7 @@ func TestMutiPublish2SamePartition(t *testing.T) {
 		args := &pb.PublishArgs{
 			Topic:     topic,
 			Partition: int32(partition),
-			Payload:   data,
+			Payload:   []byte(data),
 			Mid:       nrand(),
 		}
 		reply, err := s.ProcessPub(context.TODO(), args)
@@ -464,6 +464,65 @@ func TestMutiPublish2SamePartition(t *testing.T) {
 	assert.Equal(t, pNode.Mnum+10, newPNode.Mnum)
 }
 
+func TestAsyncPublish(t *testing.T) {
+	s, err := RunServer()
+	assert.Nil(t, err)
+	config.SrvConf.SyncWrite2disk = false
+	defer func() { config.SrvConf.SyncWrite2disk = true }()
+
+	topic := "TestAsyncPublish"
+	partition := 1
+	data := "testpayload"
+
+	cli1 := &Client{}
+	err = cli1.connect(7777)
+	assert.Nil(t, err)
+	conArgs1 := &pb.ConnectArgs{
+		Name:         "puber1",
+		Url:          "127.0.0.1:7777",
+		Topic:        topic,
+		Partition:    int32(partition),
+		Type:         Puber,
+		Id:           nrand(),
+		PubMode:      int32(PMode_Shared),
+		PartitionNum: 1,
+	}
+	_, err = s.Connect(context.TODO(), conArgs1)
+	assert.Nil(t, err)
+
+	pNode, err := rc.ZkCli.GetPartition(topic, partition)
+	assert.Nil(t, err)
+
+	var msgs sync.Map
+	var wg sync.WaitGroup
+	for i := 1; i <= 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			args := &pb.PublishArgs{
+				Topic:     topic,
+				Partition: int32(partition),
+				Payload:   []byte(data),
+				Mid:       nrand(),
+			}
+			reply, err := s.ProcessPub(context.TODO(), args)
+			assert.Nil(t, err)
+
+			_, ok := msgs.LoadOrStore(reply.Msid, args.Mid)
+			assert.False(t, ok)
+
+			msgdata, err := s.GetMsg(&msg.PullArg{Topic: topic, Partition: partition}, reply.Msid)
+			assert.Nil(t, err)
+			assert.Equal(t, args.Mid, msgdata.Mid)
+		}()
+	}
+	wg.Wait()
+
+	newPNode, err := rc.ZkCli.GetPartition(topic, partition)
+	assert.Nil(t, err)
+	assert.Equal(t, pNode.Mnum+50, newPNode.Mnum)
+}
+
 func TestMutiPublish2MutiPartition(t *testing.T) {
 	s, err := RunServer()
 	assert.Nil(t, err)
@@ -517,7 +576,7 @@ func TestMutiPublish2MutiPartition(t *testing.T) {
 			args := &pb.PublishArgs{
 				Topic:     topic1,
 				Partition: int32(partition),
-				Payload:   data,
+				Payload:   []byte(data),
 				Mid:       nrand(),
 			}
 			reply, err := s.ProcessPub(context.TODO(), args)
@@ -534,7 +593,7 @@ func TestMutiPublish2MutiPartition(t *testing.T) {
 			args := &pb.PublishArgs{
 				Topic:     topic2,
 				Partition: int32(partition),
-				Payload:   data,
+				Payload:   []byte(data),
 				Mid:       nrand(),
 			}
 			reply, err := s.ProcessPub(context.TODO(), args)
@@ -0,0 +1,212 @@
+package server
+
+import (
+	"MxcMQ-Server/logger"
+	pb "MxcMQ-Server/proto"
+	rc "MxcMQ-Server/registrationCenter"
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+func (s *Server) GetTopicStats(ctx context.Context, args *pb.GetTopicStatsArgs) (*pb.GetTopicStatsReply, error) {
+	logger.Infof("Receive GetTopicStats rq from %v", args)
+	reply := &pb.GetTopicStatsReply{}
+	tNode, err := s.meta.GetTopic(args.Topic)
+	if err != nil {
+		logger.Errorf("GetTopic failed: %v", err)
+		return reply, errors.New("404")
+	}
+
+	reply.Name = tNode.Name
+	reply.PartitionNum = int32(tNode.Pnum)
+	reply.Retention = &pb.RetentionPolicy{
+		MaxAge:        tNode.Retention.MaxAge,
+		MaxBytes:      tNode.Retention.MaxBytes,
+		MaxMsgs:       tNode.Retention.MaxMsgs,
+		DeleteUnacked: tNode.Retention.DeleteUnacked,
+		DeleteAcked:   tNode.Retention.DeleteAcked,
+		Compact:       tNode.Retention.Compact,
+	}
+	reply.Offload = &pb.OffloadPolicy{MaxHotMsgs: tNode.Offload.MaxHotMsgs}
+	reply.Quota = &pb.QuotaPolicy{
+		MaxBacklogMsgs:  tNode.Quota.MaxBacklogMsgs,
+		MaxStorageBytes: tNode.Quota.MaxStorageBytes,
+		Action:          int32(tNode.Quota.Action),
+	}
+	reply.MessageTTL = tNode.MessageTTL
+
+	for i := 1; i <= tNode.Pnum; i++ {
+		pStats, err := s.partitionStats(args.Topic, i)
+		if err != nil {
+			logger.Errorf("partitionStats failed: %v", err)
+			return reply, errors.New("404")
+		}
+		reply.Partitions = append(reply.Partitions, pStats)
+	}
+	return reply, nil
+}
+
+func (s *Server) partitionStats(topic string, partition int) (*pb.PartitionStats, error) {
+	var pNode rc.PartitionNode
+	path := fmt.Sprintf(partitionKey, topic, partition)
+	if v, ok := s.partitions.Load(path); ok {
+		p := v.(*partitionData)
+		p.mu.Lock()
+		pNode = *p.pNode
+		p.mu.Unlock()
+	} else {
+		node, err := s.meta.GetPartition(topic, partition)
+		if err != nil {
+			return nil, err
+		}
+		pNode = *node
+	}
+
+	var offloaded []*pb.OffloadRange
+	for _, r := range pNode.Offloaded {
+		offloaded = append(offloaded, &pb.OffloadRange{Start: r.Start, End: r.End})
+	}
+	return &pb.PartitionStats{
+		Partition:     int32(partition),
+		Mnum:          pNode.Mnum,
+		AckOffset:     pNode.AckOffset,
+		PushOffset:    pNode.PushOffset,
+		DeleteOffset:  pNode.DeleteOffset,
+		Size:          pNode.Size,
+		CompactOffset: pNode.CompactOffset,
+		Offloaded:     offloaded,
+	}, nil
+}
+
+func (s *Server) SetRetention(ctx context.Context, args *pb.SetRetentionArgs) (*pb.SetRetentionReply, error) {
+	logger.Infof("Receive SetRetention rq from %v", args)
+	reply := &pb.SetRetentionReply{}
+	if args.Retention == nil {
+		return reply, errors.New("retention is required")
+	}
+
+	tNode, err := s.meta.GetTopic(args.Topic)
+	if err != nil {
+		logger.Errorf("GetTopic failed: %v", err)
+		return reply, errors.New("404")
+	}
+	tNode.Retention = rc.RetentionPolicy{
+		MaxAge:        args.Retention.MaxAge,
+		MaxBytes:      args.Retention.MaxBytes,
+		MaxMsgs:       args.Retention.MaxMsgs,
+		DeleteUnacked: args.Retention.DeleteUnacked,
+		DeleteAcked:   args.Retention.DeleteAcked,
+		Compact:       args.Retention.Compact,
+	}
+	if err := s.meta.UpdateTopic(tNode); err != nil {
+		logger.Errorf("UpdateTopic failed: %v", err)
+		return reply, err
+	}
+	return reply, nil
+}
+
+func (s *Server) SetOffload(ctx context.Context, args *pb.SetOffloadArgs) (*pb.SetOffloadReply, error) {
+	logger.Infof("Receive SetOffload rq from %v", args)
+	reply := &pb.SetOffloadReply{}
+	if args.Offload == nil {
+		return reply, errors.New("offload is required")
+	}
+
+	tNode, err := s.meta.GetTopic(args.Topic)
+	if err != nil {
+		logger.Errorf("GetTopic failed: %v", err)
+		return reply, errors.New("404")
+	}
+	tNode.Offload = rc.OffloadPolicy{MaxHotMsgs: args.Offload.MaxHotMsgs}
+	if err := s.meta.UpdateTopic(tNode); err != nil {
+		logger.Errorf("UpdateTopic failed: %v", err)
+		return reply, err
+	}
+	return reply, nil
+}
+
+func (s *Server) SetQuota(ctx context.Context, args *pb.SetQuotaArgs) (*pb.SetQuotaReply, error) {
+	logger.Infof("Receive SetQuota rq from %v", args)
+	reply := &pb.SetQuotaReply{}
+	if args.Quota == nil {
+		return reply, errors.New("quota is required")
+	}
+	switch args.Quota.Action {
+	case rc.QuotaReject, rc.QuotaHold, rc.QuotaEvict:
+	default:
+		return reply, fmt.Errorf("unknown quota action: %v", args.Quota.Action)
+	}
+
+	tNode, err := s.meta.GetTopic(args.Topic)
+	if err != nil {
+		logger.Errorf("GetTopic failed: %v", err)
+		return reply, errors.New("404")
+	}
+	tNode.Quota = rc.QuotaPolicy{
+		MaxBacklogMsgs:  args.Quota.MaxBacklogMsgs,
+		MaxStorageBytes: args.Quota.MaxStorageBytes,
+		Action:          int(args.Quota.Action),
+	}
+	if err := s.meta.UpdateTopic(tNode); err != nil {
+		logger.Errorf("UpdateTopic failed: %v", err)
+		return reply, err
+	}
+
+	// partitions owned by other brokers pick it up with their reaper
+	for i := 1; i <= tNode.Pnum; i++ {
+		if v, ok := s.partitions.Load(fmt.Sprintf(partitionKey, args.Topic, i)); ok {
+			p := v.(*partitionData)
+			quota := tNode.Quota
+			p.mu.Lock()
+			p.quota = &quota
+			p.mu.Unlock()
+		}
+	}
+	return reply, nil
+}
+
+func (s *Server) SetMessageTTL(ctx context.Context, args *pb.SetMessageTTLArgs) (*pb.SetMessageTTLReply, error) {
+	logger.Infof("Receive SetMessageTTL rq from %v", args)
+	reply := &pb.SetMessageTTLReply{}
+	if args.MessageTTL < 0 {
+		return reply, fmt.Errorf("negative message ttl: %v", args.MessageTTL)
+	}
+
+	tNode, err := s.meta.GetTopic(args.Topic)
+	if err != nil {
+		logger.Errorf("GetTopic failed: %v", err)
+		return reply, errors.New("404")
+	}
+	tNode.MessageTTL = args.MessageTTL
+	if err := s.meta.UpdateTopic(tNode); err != nil {
+		logger.Errorf("UpdateTopic failed: %v", err)
+		return reply, err
+	}
+
+	// partitions owned by other brokers pick it up with their reaper
+	for i := 1; i <= tNode.Pnum; i++ {
+		if v, ok := s.partitions.Load(fmt.Sprintf(partitionKey, args.Topic, i)); ok {
+			p := v.(*partitionData)
+			p.mu.Lock()
+			p.messageTTL = tNode.MessageTTL
+			p.mu.Unlock()
+		}
+	}
+	return reply, nil
+}
+
+func (s *Server) GetBrokerStats(ctx context.Context, args *pb.GetBrokerStatsArgs) (*pb.GetBrokerStatsReply, error) {
+	logger.Infof("Receive GetBrokerStats rq from %v", args)
+	hits, misses, size, n := s.cache.stats()
+	return &pb.GetBrokerStatsReply{
+		Name:        s.Info.Name,
+		CacheHits:   hits,
+		CacheMisses: misses,
+		CacheBytes:  size,
+		CacheMsgs:   int64(n),
+		CorruptMsgs: atomic.LoadUint64(&s.corruptMsgs),
+		ExpiredMsgs: atomic.LoadUint64(&s.expiredMsgs),
+	}, nil
+}
@@ -11,16 +11,38 @@ type subcription struct {
 	mu   sync.Mutex
 	Data *subcriptionData
 	// Clients map[string]*client
-	clients map[string]*grpc.ClientConn
-	Ackch   chan uint64
+	clients   map[string]grpc.ClientConnInterface
+	Ackch     chan uint64
+	keyShared *keyShared // Key_Shared subscriptions only
+	delayed   delayedIndex
+
+	deliveries   map[uint64]*delivery // failed deliveries of unacked messages
+	redeliveries []uint64             // sorted
+	nacked       delayedIndex         // redelivered after their backoff
+
+	inflight     map[uint64]inflightMsg // delivered, waiting for the ack
+	ackDeadlines delayedIndex
 }
 
 // todo: need to persist ? or to rc ?
 type subcriptionData struct {
 	Meta       rc.SubcriptionNode
 	Subers     map[string]string
-	AckOffset  uint64
+	AckOffset  uint64 // mark delete position, every msid up to it is acked
 	PushOffset uint64
+	Acked      []AckRange // acked above AckOffset, sorted
+
+	// see delay and due
+	DelayedFloor uint64
+	DelayedAt    int64
+	DelayedMsid  uint64
+
+	HeldFloor uint64 // lowest msid held for a Key_Shared consumer, 0 if none
+
+	ExpiredMsgs uint64 // skipped after their ttl
+	DeadLetter  DeadLetterPolicy
+	NackBackoff NackBackoff
+	AckTimeout  int64 // millisecond, 0 means never
 }
 
 type sublist struct {
@@ -38,8 +60,10 @@ func NewSubcription() *subcription {
 		Subers: make(map[string]string),
 	}
 	sub := &subcription{
-		clients: make(map[string]*grpc.ClientConn),
-		Data:    data,
+		clients:    make(map[string]grpc.ClientConnInterface),
+		Data:       data,
+		deliveries: make(map[uint64]*delivery),
+		inflight:   make(map[uint64]inflightMsg),
 	}
 	return sub
 }
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"MxcMQ-Server/logger"
+	"MxcMQ-Server/msg"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// number of messages read per batch when a cursor moves past expired ones
+const expireBatchMsgs = 1000
+
+// expired tells if m outlived its own ttl, or the ttl of the topic given in
+// seconds.
+func expired(m *msg.MsgData, topicTTL int64, now int64) bool {
+	if m.ExpireAt != 0 {
+		return m.ExpireAt <= now
+	}
+	return topicTTL > 0 && m.PublishTime+topicTTL*1000 <= now
+}
+
+func (s *Server) messageTTLOf(p *partitionData) int64 {
+	if _, err := s.quotaOf(p); err != nil {
+		logger.Errorf("quotaOf failed: %v", err)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.messageTTL
+}
+
+// expire counts m as expired instead of delivered by sub, it is acked.
+func (s *Server) expire(sub *subcription, m *msg.MsgData) {
+	sub.ack(m.Msid)
+	sub.Data.ExpiredMsgs++
+	atomic.AddUint64(&s.expiredMsgs, 1)
+	logger.Debugf("message %v of %v expired", m.Msid, sub.Data.Meta.Name)
+}
+
+// skipExpired moves the cursors of the subscriptions of p past the expired
+// messages at their head, so an idle subscription does not keep a backlog
+// nobody can receive.
+func (s *Server) skipExpired(p *partitionData) {
+	ttl := s.messageTTLOf(p)
+	if ttl <= 0 {
+		return
+	}
+	p.mu.Lock()
+	topic, partition, mnum := p.pNode.TopicName, p.pNode.ID, p.pNode.Mnum
+	p.mu.Unlock()
+
+	sNodes, err := s.meta.GetSubs(topic, partition)
+	if err != nil {
+		logger.Errorf("GetSubs failed: %v", err)
+		return
+	}
+	for _, sNode := range sNodes {
+		// only the subscriptions served by this broker
+		s.Sl.mu.RLock()
+		sub, ok := s.Sl.Subs[fmt.Sprintf(subcriptionKey, topic, partition, sNode.Name)]
+		s.Sl.mu.RUnlock()
+		if !ok {
+			continue
+		}
+		if err := s.advanceExpired(sub, ttl, mnum); err != nil {
+			logger.Errorf("skip expired messages of %v failed: %v", sNode.Name, err)
+		}
+	}
+}
+
+// advanceExpired moves the cursor of sub past the expired messages at its
+// head, up to mnum. A batch is read without sub.mu, which is only held to
+// move the cursor, and the scan stops once a pull moved it meanwhile.
+func (s *Server) advanceExpired(sub *subcription, ttl int64, mnum uint64) error {
+	sub.mu.Lock()
+	meta, cursor := sub.Data.Meta, sub.Data.PushOffset
+	sub.mu.Unlock()
+
+	now := time.Now().UnixMilli()
+	for start := cursor; start <= mnum; start += expireBatchMsgs {
+		end := start + expireBatchMsgs - 1
+		if end > mnum {
+			end = mnum
+		}
+		msgs, err := s.readMsgs(meta.TopicName, meta.Partition, start, end)
+		if err != nil {
+			return err
+		}
+		var done bool
+		cursor, done, err = s.expireBatch(sub, cursor, msgs, ttl, now)
+		if done || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expireBatch expires the messages of msgs at the head of sub if its cursor
+// is still at cursor, it returns the new cursor and tells if the scan is done.
+func (s *Server) expireBatch(sub *subcription, cursor uint64, msgs []*msg.MsgData, ttl int64, now int64) (uint64, bool, error) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if sub.Data.PushOffset != cursor {
+		return cursor, true, nil
+	}
+	done := false
+	for _, m := range msgs {
+		if m.Msid < cursor {
+			continue
+		}
+		if !expired(m, ttl, now) {
+			done = true
+			break
+		}
+		s.expire(sub, m)
+		sub.Data.PushOffset = m.Msid + 1
+	}
+	if sub.Data.PushOffset == cursor {
+		return cursor, done, nil
+	}
+	logger.Infof("skip expired messages %v-%v of %v", cursor, sub.Data.PushOffset-1, sub.Data.Meta.Name)
+	return sub.Data.PushOffset, done, s.PutSubcription(sub)
+}
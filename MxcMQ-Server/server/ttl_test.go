@@ -0,0 +1,64 @@
+package server
+
+import (
+	"MxcMQ-Server/msg"
+	rc "MxcMQ-Server/registrationCenter"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpired(t *testing.T) {
+	assert.False(t, expired(&msg.MsgData{PublishTime: 1000}, 0, 5000))
+	assert.True(t, expired(&msg.MsgData{PublishTime: 1000}, 4, 5000))
+	assert.False(t, expired(&msg.MsgData{PublishTime: 1000}, 5, 5000))
+	// the ttl of the message wins over the one of the topic
+	assert.False(t, expired(&msg.MsgData{PublishTime: 1000, ExpireAt: 6000}, 1, 5000))
+	assert.True(t, expired(&msg.MsgData{PublishTime: 1000, ExpireAt: 2000}, 0, 5000))
+}
+
+func TestAdvanceExpired(t *testing.T) {
+	s, _ := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1})
+	now := time.Now().UnixMilli()
+	for i, at := range []int64{now - 20000, now - 15000, now, now - 20000} {
+		s.store.Append("t", 1, &msg.MsgData{Msid: uint64(i + 1), PublishTime: at})
+	}
+
+	sub := addSub(t, s, "t", "s1", Exclusive)
+	assert.Nil(t, s.advanceExpired(sub, 10, 4))
+	assert.Equal(t, uint64(3), sub.Data.PushOffset)
+	assert.Equal(t, uint64(2), sub.Data.ExpiredMsgs)
+	assert.Equal(t, uint64(2), s.expiredMsgs)
+}
+
+func TestAdvanceExpiredBatches(t *testing.T) {
+	s, _ := newPullServer(t, &rc.TopicNode{Name: "t", Pnum: 1})
+	now := time.Now().UnixMilli()
+	n := uint64(2*expireBatchMsgs + 500)
+	for i := uint64(1); i <= n; i++ {
+		s.store.Append("t", 1, &msg.MsgData{Msid: i, PublishTime: now - 20000})
+	}
+	s.store.Append("t", 1, &msg.MsgData{Msid: n + 1, PublishTime: now})
+
+	sub := addSub(t, s, "t", "s1", Exclusive)
+	assert.Nil(t, s.advanceExpired(sub, 10, n+1))
+	assert.Equal(t, n+1, sub.Data.PushOffset)
+	assert.Equal(t, n, sub.Data.ExpiredMsgs)
+	// every batch is persisted
+	stored, err := s.GetSubcription(&sub.Data.Meta)
+	assert.Nil(t, err)
+	assert.Equal(t, n+1, stored.Data.PushOffset)
+
+	// a batch read before a pull moved the cursor is dropped
+	sub = addSub(t, s, "t", "s2", Exclusive)
+	msgs, err := s.readMsgs("t", 1, 1, 10)
+	assert.Nil(t, err)
+	sub.Data.PushOffset = 5
+	cursor, done, err := s.expireBatch(sub, 1, msgs, 10, now)
+	assert.Nil(t, err)
+	assert.True(t, done)
+	assert.Equal(t, uint64(1), cursor)
+	assert.Equal(t, uint64(5), sub.Data.PushOffset)
+	assert.Equal(t, uint64(0), sub.Data.ExpiredMsgs)
+}
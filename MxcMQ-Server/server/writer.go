@@ -0,0 +1,154 @@
+package server
+
+import (
+	"MxcMQ-Server/config"
+	"MxcMQ-Server/logger"
+	"MxcMQ-Server/msg"
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// states of a pubRequest
+const (
+	reqQueued   int32 = iota
+	reqTaken          // in a batch, its result stands
+	reqCanceled       // the publisher gave up, it is never committed
+)
+
+// pubRequest is a publish waiting in the write-behind queue of a partition,
+// done receives the result of the batch it was committed with.
+type pubRequest struct {
+	msgs  []*msg.MsgData // more than one for a batched publish
+	done  chan error
+	state int32
+}
+
+// commitMsgs gives msgs the next msids of the partition and persists them
+// together with the new Mnum. Duplicate publishes are dropped, see dedup.
+// Either every message of msgs is committed or none is.
+func (s *Server) commitMsgs(p *partitionData, msgs ...*msg.MsgData) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	msgs, seqs := dedup(p.pNode, msgs)
+	if len(msgs) == 0 {
+		return nil
+	}
+	var size int64
+	for i, m := range msgs {
+		m.Msid = p.pNode.Mnum + uint64(i) + 1
+		size += int64(len(m.Payload))
+	}
+	if err := s.store.Append(p.pNode.TopicName, p.pNode.ID, msgs...); err != nil {
+		logger.Errorf("Append failed: %v", err)
+		return err
+	}
+
+	mnum, prevSize, version := p.pNode.Mnum, p.pNode.Size, p.pNode.Version
+	prevSeqs := sequencesOf(p.pNode, seqs)
+	p.pNode.Mnum += uint64(len(msgs))
+	p.pNode.Size += size
+	persistSequences(p.pNode, seqs, time.Now().UnixMilli())
+	if err := s.meta.UpdatePartition(p.pNode); err != nil {
+		logger.Errorf("UpdatePartition: %v", err)
+		pNode, _ := s.meta.GetPartition(p.pNode.TopicName, p.pNode.ID)
+		logger.Debugln(*p.pNode, " ", pNode)
+		// the batch fails, its msids are taken by the next one
+		p.pNode.Mnum, p.pNode.Size, p.pNode.Version = mnum, prevSize, version
+		restoreSequences(p.pNode, prevSeqs)
+		if terr := s.store.Truncate(p.pNode.TopicName, p.pNode.ID, mnum+1); terr != nil {
+			logger.Errorf("Truncate failed: %v", terr)
+		}
+		for _, m := range msgs {
+			m.Msid = 0
+		}
+		return err
+	}
+
+	for _, m := range msgs {
+		s.cache.put(p.pNode.TopicName, p.pNode.ID, m)
+	}
+	return nil
+}
+
+// writeBehind queues msgs to the group commit of its partition and waits
+// until the batch holding msgs is persisted.
+func (s *Server) writeBehind(ctx context.Context, p *partitionData, msgs ...*msg.MsgData) error {
+	p.writerOnce.Do(func() {
+		p.pending = make(chan *pubRequest, config.SrvConf.AsyncWriteMsglimit)
+		go s.groupCommit(p)
+	})
+
+	req := &pubRequest{
+		msgs: msgs,
+		done: make(chan error, 1),
+	}
+	select {
+	case p.pending <- req:
+	case <-ctx.Done():
+		return errors.New("publish canceled before queued")
+	}
+
+	select {
+	case err := <-req.done:
+		return err
+	case <-ctx.Done():
+		if atomic.CompareAndSwapInt32(&req.state, reqQueued, reqCanceled) {
+			return errors.New("publish canceled before committed")
+		}
+		// the batch holding msgs is being committed, a retry would store it twice
+		return <-req.done
+	}
+}
+
+// groupCommit takes every publish pending on p, up to AsyncWriteMsglimit,
+// and commits them with a single store append and partition update.
+func (s *Server) groupCommit(p *partitionData) {
+	limit := config.SrvConf.AsyncWriteMsglimit
+	if limit <= 0 {
+		limit = 1
+	}
+
+	for {
+		batch := []*pubRequest{<-p.pending}
+	collect:
+		for len(batch) < limit {
+			select {
+			case req := <-p.pending:
+				batch = append(batch, req)
+			default:
+				break collect
+			}
+		}
+
+		batch = claim(batch)
+		if len(batch) == 0 {
+			continue
+		}
+		msgs := make([]*msg.MsgData, 0, len(batch))
+		for _, req := range batch {
+			msgs = append(msgs, req.msgs...)
+		}
+		err := s.commitMsgs(p, msgs...)
+		if err != nil {
+			logger.Errorf("group commit of %v msgs failed: %v", len(msgs), err)
+		}
+		for _, req := range batch {
+			req.done <- err
+		}
+	}
+}
+
+// claim takes the requests of batch whose publisher still waits, the
+// canceled ones are dropped.
+func claim(batch []*pubRequest) []*pubRequest {
+	taken := batch[:0]
+	for _, req := range batch {
+		if atomic.CompareAndSwapInt32(&req.state, reqQueued, reqTaken) {
+			taken = append(taken, req)
+		}
+	}
+	return taken
+}
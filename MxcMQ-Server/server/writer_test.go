@@ -0,0 +1,75 @@
+package server
+
+import (
+	"MxcMQ-Server/config"
+	"MxcMQ-Server/msg"
+	"MxcMQ-Server/persist"
+	rc "MxcMQ-Server/registrationCenter"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteBehindCancel(t *testing.T) {
+	s := &Server{}
+	p := &partitionData{pending: make(chan *pubRequest, 1)}
+	p.writerOnce.Do(func() {})
+
+	// canceled while queued, the writer must not commit it
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() { errc <- s.writeBehind(ctx, p, &msg.MsgData{}) }()
+	req := <-p.pending
+	cancel()
+	assert.NotNil(t, <-errc)
+	assert.Empty(t, claim([]*pubRequest{req}))
+
+	// canceled once taken, the publisher gets the result of the batch
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() { errc <- s.writeBehind(ctx, p, &msg.MsgData{}) }()
+	req = <-p.pending
+	assert.Equal(t, []*pubRequest{req}, claim([]*pubRequest{req}))
+	cancel()
+	req.done <- nil
+	assert.Nil(t, <-errc)
+}
+
+func TestCommitMsgsRollback(t *testing.T) {
+	config.SrvConf.BrokerDeduplicationEnabled = true
+	defer func() { config.SrvConf.BrokerDeduplicationEnabled = false }()
+
+	meta := newMemMeta()
+	pNode := &rc.PartitionNode{TopicName: "t", ID: 1}
+	assert.Nil(t, meta.RegisterPnode(pNode))
+	s := &Server{store: persist.NewMemoryStore(), meta: meta, cache: newMsgCache(1 << 20)}
+	p := &partitionData{pNode: pNode}
+
+	assert.Nil(t, s.commitMsgs(p, &msg.MsgData{ProducerName: "p1", SequenceId: 1, Payload: []byte("a")}))
+
+	// nothing of a batch zk did not take stays behind
+	batch := []*msg.MsgData{
+		{ProducerName: "p1", SequenceId: 2, Payload: []byte("bb")},
+		{ProducerName: "p2", SequenceId: 1, Payload: []byte("cc")},
+	}
+	meta.failUpdate = errors.New("connection lost")
+	assert.NotNil(t, s.commitMsgs(p, batch...))
+	assert.Equal(t, uint64(1), pNode.Mnum)
+	assert.Equal(t, int64(1), pNode.Size)
+	assert.Equal(t, map[string]int64{"p1": 1}, pNode.Sequences)
+	last, err := s.store.LastOffset("t", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), last)
+	_, ok := s.cache.get("t", 1, 2)
+	assert.False(t, ok)
+
+	// a retry is neither dropped as duplicate nor refused by zk
+	assert.Nil(t, s.commitMsgs(p, batch...))
+	assert.Equal(t, uint64(3), batch[1].Msid)
+	stored, err := meta.GetPartition("t", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), stored.Mnum)
+	assert.Equal(t, int64(5), stored.Size)
+	assert.Equal(t, map[string]int64{"p1": 2, "p2": 1}, stored.Sequences)
+}